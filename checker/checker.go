@@ -0,0 +1,1191 @@
+package checker
+
+import (
+	"fmt"
+	"glox/parser"
+	"glox/scanner"
+	"reflect"
+	"slices"
+)
+
+type Checker struct {
+	scopes             []map[string]*loxType
+	classes            map[string]*classInfo
+	traits             map[string]*classInfo
+	reassigned         map[string]bool
+	assignedProperties map[string]bool
+	currentClass       *classInfo
+	currentFunction    *signature
+	errors             []error
+}
+
+func New() *Checker {
+	globals := map[string]*loxType{
+		"clock":          newFunctionType(&signature{name: "clock", parameters: []*loxType{}, returnType: numberType}),
+		"str":            newFunctionType(&signature{name: "str", parameters: []*loxType{anyType}, returnType: stringType}),
+		"append":         newFunctionType(&signature{name: "append", parameters: []*loxType{arrayType, anyType}, returnType: arrayType}),
+		"len":            newFunctionType(&signature{name: "len", parameters: []*loxType{arrayType}, returnType: numberType}),
+		"bigint":         newFunctionType(&signature{name: "bigint", parameters: []*loxType{anyType}, returnType: bigIntType}),
+		"decimal":        newFunctionType(&signature{name: "decimal", parameters: []*loxType{anyType}, returnType: decimalType}),
+		"decimalContext": newFunctionType(&signature{name: "decimalContext", parameters: []*loxType{numberType, stringType}, returnType: nilType}),
+		"round":          newFunctionType(&signature{name: "round", parameters: []*loxType{anyType, numberType}, returnType: anyType}),
+		"inspect":        newFunctionType(&signature{name: "inspect", parameters: []*loxType{anyType}, returnType: stringType}),
+		"freeze":         newFunctionType(&signature{name: "freeze", parameters: []*loxType{anyType}, returnType: anyType}),
+		"type":           newFunctionType(&signature{name: "type", parameters: []*loxType{anyType}, returnType: stringType}),
+		"fields":         newFunctionType(&signature{name: "fields", parameters: []*loxType{anyType}, returnType: arrayType}),
+		"methods":        newFunctionType(&signature{name: "methods", parameters: []*loxType{classType}, returnType: arrayType}),
+		"getters":        newFunctionType(&signature{name: "getters", parameters: []*loxType{classType}, returnType: arrayType}),
+		"staticMethods":  newFunctionType(&signature{name: "staticMethods", parameters: []*loxType{classType}, returnType: arrayType}),
+		"superclass":     newFunctionType(&signature{name: "superclass", parameters: []*loxType{classType}, returnType: anyType}),
+		"traits":         newFunctionType(&signature{name: "traits", parameters: []*loxType{classType}, returnType: arrayType}),
+		"bind":           newFunctionType(&signature{name: "bind", parameters: []*loxType{anyType}, variadic: true, returnType: newFunctionType(nil)}),
+	}
+
+	return &Checker{
+		scopes:             []map[string]*loxType{globals},
+		classes:            make(map[string]*classInfo),
+		traits:             make(map[string]*classInfo),
+		reassigned:         make(map[string]bool),
+		assignedProperties: make(map[string]bool),
+		errors:             make([]error, 0),
+	}
+}
+
+// Check infers types of the program and returns every type mismatch it can prove. Unannotated
+// code is typed as Any wherever the type can't be inferred, so it is never reported.
+func (c *Checker) Check(statements []parser.Stmt) []error {
+	walk(statements, func(node any) {
+		switch node := node.(type) {
+		case parser.AssignmentExpr:
+			c.reassigned[node.Name.Lexeme] = true
+		case parser.SetExpr:
+			c.assignedProperties[node.Name.Lexeme] = true
+		case parser.DestructureAssignmentExpr:
+			c.markReassigned(node.Targets)
+			c.markReassigned([]parser.Expr{node.Rest})
+		}
+	})
+
+	c.declareTopLevel(statements)
+
+	for _, stmt := range statements {
+		c.checkStmt(stmt)
+	}
+
+	return c.errors
+}
+
+func (c *Checker) markReassigned(targets []parser.Expr) {
+	for _, target := range targets {
+		switch target := target.(type) {
+		case parser.VariableExpr:
+			c.reassigned[target.Name.Lexeme] = true
+		case parser.GetExpr:
+			c.assignedProperties[target.Name.Lexeme] = true
+		case parser.ArrayExpr:
+			c.markReassigned(target.Elements)
+		case parser.SpreadExpr:
+			c.markReassigned([]parser.Expr{target.Expr})
+		}
+	}
+}
+
+func (c *Checker) newError(token scanner.Token, message string) {
+	err := &Error{Token: token, Message: message}
+
+	// Annotations are resolved both when declarations are registered and when they are checked.
+	for _, reported := range c.errors {
+		if reported.Error() == err.Error() {
+			return
+		}
+	}
+
+	c.errors = append(c.errors, err)
+}
+
+// declareTopLevel registers top-level traits, classes and functions up front, so code can refer to
+// declarations that appear later in the file.
+func (c *Checker) declareTopLevel(statements []parser.Stmt) {
+	for _, stmt := range statements {
+		if trait, ok := stmt.(parser.TraitStmt); ok {
+			c.declareTrait(trait)
+		}
+	}
+
+	for _, stmt := range statements {
+		if class, ok := stmt.(parser.ClassStmt); ok {
+			c.classes[class.Name.Lexeme] = newClassInfo(class.Name.Lexeme)
+		}
+	}
+
+	for _, stmt := range statements {
+		if class, ok := stmt.(parser.ClassStmt); ok {
+			c.declareClass(class)
+		}
+	}
+
+	for _, stmt := range statements {
+		if function, ok := stmt.(parser.FunctionStmt); ok {
+			c.define(function.Name.Lexeme, newFunctionType(c.functionSignature(function.Name.Lexeme, function.Parameters, function.ReturnType)))
+		}
+	}
+}
+
+func (c *Checker) beginScope() {
+	c.scopes = append(c.scopes, make(map[string]*loxType))
+}
+
+func (c *Checker) endScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+func (c *Checker) define(name string, t *loxType) {
+	c.scopes[len(c.scopes)-1][name] = t
+}
+
+func (c *Checker) lookup(name string) *loxType {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if t, ok := c.scopes[i][name]; ok {
+			return t
+		}
+	}
+
+	return anyType
+}
+
+func (c *Checker) resolveAnnotation(annotation *parser.TypeAnnotation) *loxType {
+	if annotation == nil {
+		return anyType
+	}
+
+	var t *loxType
+	name := annotation.Name.Lexeme
+
+	if kind, ok := builtinTypes[name]; ok {
+		t = &loxType{kind: kind}
+	} else if class, ok := c.classes[name]; ok {
+		t = newInstanceType(class)
+	} else {
+		c.newError(annotation.Name, fmt.Sprintf("Unknown type '%s'.", name))
+		return anyType
+	}
+
+	t.nullable = annotation.Nullable
+
+	return t
+}
+
+func (c *Checker) functionSignature(name string, parameters []parser.Parameter, returnType *parser.TypeAnnotation) *signature {
+	sig := &signature{name: name, parameters: make([]*loxType, 0, len(parameters)), returnType: c.resolveAnnotation(returnType)}
+
+	for _, parameter := range parameters {
+		if parameter.Rest {
+			sig.variadic = true
+			continue
+		}
+
+		if parameter.Default != nil {
+			sig.optional++
+		}
+		sig.parameters = append(sig.parameters, c.resolveAnnotation(parameter.Type))
+		sig.names = append(sig.names, parameter.Name.Lexeme)
+	}
+
+	return sig
+}
+
+func (c *Checker) addMembers(class *classInfo, methods []parser.FunctionStmt, staticMethods []parser.FunctionStmt) {
+	for _, method := range methods {
+		if method.Parameters == nil {
+			class.getters[method.Name.Lexeme] = c.resolveAnnotation(method.ReturnType)
+		} else {
+			class.methods[method.Name.Lexeme] = c.functionSignature(method.Name.Lexeme, method.Parameters, method.ReturnType)
+		}
+	}
+
+	for _, method := range staticMethods {
+		if method.Parameters == nil {
+			class.staticGetters[method.Name.Lexeme] = c.resolveAnnotation(method.ReturnType)
+		} else {
+			class.staticMethods[method.Name.Lexeme] = c.functionSignature(method.Name.Lexeme, method.Parameters, method.ReturnType)
+		}
+	}
+}
+
+func (c *Checker) addSetters(class *classInfo, setters []parser.FunctionStmt, staticSetters []parser.FunctionStmt) {
+	for _, setter := range setters {
+		class.setters[setter.Name.Lexeme] = c.setterType(setter)
+	}
+
+	for _, setter := range staticSetters {
+		class.staticSetters[setter.Name.Lexeme] = c.setterType(setter)
+	}
+}
+
+func (c *Checker) addFields(class *classInfo, fields []parser.VarStmt, staticFields []parser.VarStmt) {
+	for _, field := range fields {
+		class.fields[field.Name.Lexeme] = c.resolveAnnotation(field.Type)
+	}
+
+	for _, field := range staticFields {
+		class.staticFields[field.Name.Lexeme] = c.resolveAnnotation(field.Type)
+	}
+}
+
+// checkFields checks field initializers against the declared field types.
+func (c *Checker) checkFields(class *classInfo, fields []parser.VarStmt, staticFields []parser.VarStmt) {
+	previousClass := c.currentClass
+	c.currentClass = class
+	defer func() {
+		c.currentClass = previousClass
+	}()
+
+	for _, field := range fields {
+		c.checkFieldInitializer(field)
+	}
+
+	c.currentClass = nil
+	for _, field := range staticFields {
+		c.checkFieldInitializer(field)
+	}
+}
+
+func (c *Checker) checkFieldInitializer(field parser.VarStmt) {
+	if field.Initializer == nil {
+		return
+	}
+
+	declared := c.resolveAnnotation(field.Type)
+	value := c.checkExpr(field.Initializer)
+	if !isAssignable(declared, value) {
+		c.newError(field.Name, fmt.Sprintf("Can't assign %s to field '%s' of type %s.", value, field.Name.Lexeme, declared))
+	}
+}
+
+func (c *Checker) setterType(setter parser.FunctionStmt) *loxType {
+	if len(setter.Parameters) != 1 {
+		return anyType
+	}
+
+	return c.resolveAnnotation(setter.Parameters[0].Type)
+}
+
+func (c *Checker) declareTrait(stmt parser.TraitStmt) *classInfo {
+	if trait, ok := c.traits[stmt.Name.Lexeme]; ok {
+		return trait
+	}
+
+	trait := newClassInfo(stmt.Name.Lexeme)
+	c.traits[stmt.Name.Lexeme] = trait
+	c.useTraits(trait, stmt.Traits)
+	c.addMembers(trait, stmt.Required, nil)
+	c.addMembers(trait, stmt.Methods, stmt.StaticMethods)
+	c.addSetters(trait, stmt.Setters, stmt.StaticSetters)
+	c.addFields(trait, stmt.Fields, stmt.StaticFields)
+	c.define(stmt.Name.Lexeme, &loxType{kind: kindTrait})
+
+	return trait
+}
+
+func (c *Checker) declareClass(stmt parser.ClassStmt) *classInfo {
+	class, ok := c.classes[stmt.Name.Lexeme]
+	if !ok {
+		class = newClassInfo(stmt.Name.Lexeme)
+		c.classes[stmt.Name.Lexeme] = class
+	}
+
+	c.define(stmt.Name.Lexeme, newClassType(class))
+	if class.declared {
+		return class
+	}
+	class.declared = true
+
+	if !reflect.ValueOf(stmt.Superclass).IsZero() {
+		superclass, ok := c.classes[stmt.Superclass.Name.Lexeme]
+		if ok {
+			class.superclass = superclass
+		} else {
+			class.open = true
+		}
+	}
+
+	c.useTraits(class, stmt.Traits)
+
+	c.addMembers(class, stmt.AbstractMethods, nil)
+	c.addMembers(class, stmt.Methods, stmt.StaticMethods)
+	c.addSetters(class, stmt.Setters, stmt.StaticSetters)
+	c.addFields(class, stmt.Fields, stmt.StaticFields)
+
+	return class
+}
+
+// useTraits copies the members of the used traits into class, under their aliases as well and
+// leaving out the excluded ones. Unknown traits make the class open.
+func (c *Checker) useTraits(class *classInfo, uses []parser.TraitUse) {
+	for _, use := range uses {
+		trait, ok := c.traits[use.Trait.Name.Lexeme]
+		if !ok {
+			class.open = true
+			continue
+		}
+
+		for name, method := range trait.methods {
+			for _, alias := range traitMemberNames(use, name) {
+				class.methods[alias] = method
+			}
+		}
+		for name, getter := range trait.getters {
+			for _, alias := range traitMemberNames(use, name) {
+				class.getters[alias] = getter
+			}
+		}
+		for name, method := range trait.staticMethods {
+			for _, alias := range traitMemberNames(use, name) {
+				class.staticMethods[alias] = method
+			}
+		}
+		for name, getter := range trait.staticGetters {
+			for _, alias := range traitMemberNames(use, name) {
+				class.staticGetters[alias] = getter
+			}
+		}
+		for name, setter := range trait.setters {
+			for _, alias := range traitMemberNames(use, name) {
+				class.setters[alias] = setter
+			}
+		}
+		for name, setter := range trait.staticSetters {
+			for _, alias := range traitMemberNames(use, name) {
+				class.staticSetters[alias] = setter
+			}
+		}
+		for name, field := range trait.fields {
+			class.fields[name] = field
+		}
+		for name, field := range trait.staticFields {
+			class.staticFields[name] = field
+		}
+	}
+}
+
+// traitMemberNames returns the names a trait member is available under in the using class.
+func traitMemberNames(use parser.TraitUse, name string) []string {
+	names := make([]string, 0, 1)
+	if !slices.ContainsFunc(use.Excluded, func(excluded scanner.Token) bool { return excluded.Lexeme == name }) {
+		names = append(names, name)
+	}
+
+	for _, alias := range use.Aliases {
+		if alias.Member.Lexeme == name {
+			names = append(names, alias.Alias.Lexeme)
+		}
+	}
+
+	return names
+}
+
+func (c *Checker) checkExpr(expr parser.Expr) *loxType {
+	t, _ := expr.Accept(c)
+	return t.(*loxType)
+}
+
+func (c *Checker) checkStmt(stmt parser.Stmt) {
+	_, _ = stmt.Accept(c)
+}
+
+func (c *Checker) checkStmts(statements []parser.Stmt) {
+	for _, stmt := range statements {
+		c.checkStmt(stmt)
+	}
+}
+
+func (c *Checker) checkFunction(sig *signature, parameters []parser.Parameter, body []parser.Stmt) {
+	previousFunction := c.currentFunction
+	c.currentFunction = sig
+	c.beginScope()
+
+	defer func() {
+		c.currentFunction = previousFunction
+		c.endScope()
+	}()
+
+	for idx, parameter := range parameters {
+		if parameter.Rest {
+			c.define(parameter.Name.Lexeme, arrayType)
+			continue
+		}
+
+		if parameter.Default != nil {
+			value := c.checkExpr(parameter.Default)
+			if !isAssignable(sig.parameters[idx], value) {
+				c.newError(parameter.Name, fmt.Sprintf("Default value of parameter '%s' expects %s, got %s.", parameter.Name.Lexeme, sig.parameters[idx], value))
+			}
+		}
+
+		if parameter.Pattern != nil {
+			c.checkPattern(parameter.Pattern)
+			continue
+		}
+		c.define(parameter.Name.Lexeme, sig.parameters[idx])
+	}
+
+	c.checkStmts(body)
+}
+
+func (c *Checker) checkCall(callee *loxType, token scanner.Token, arguments callArguments) *loxType {
+	var sig *signature
+
+	switch callee.kind {
+	case kindAny:
+		return anyType
+	case kindFunction:
+		if callee.signature == nil {
+			return anyType
+		}
+		sig = callee.signature
+	case kindClass:
+		if callee.class == nil {
+			return anyType
+		}
+
+		instance := newInstanceType(callee.class)
+		initializer, ok := callee.class.findProperty("init")
+		if !ok {
+			initializer = newFunctionType(&signature{name: "init", parameters: []*loxType{}})
+		}
+		if initializer.signature != nil {
+			c.checkArguments(initializer.signature, token, arguments)
+		}
+
+		return instance
+	case kindInstance:
+		method, ok := callee.class.findProperty("__call__")
+		if !ok {
+			c.newError(token, fmt.Sprintf("Can only call functions and classes, got %s.", callee))
+			return anyType
+		}
+		return c.checkCall(method, token, arguments)
+	default:
+		c.newError(token, fmt.Sprintf("Can only call functions and classes, got %s.", callee))
+		return anyType
+	}
+
+	c.checkArguments(sig, token, arguments)
+
+	return sig.returnType
+}
+
+// callArguments are the types of a call's arguments. Spread arguments make the number of
+// positional arguments unknown, so only the ones before the first spread are checked.
+type callArguments struct {
+	positional []*loxType
+	named      []parser.NamedArgument
+	namedTypes []*loxType
+	spread     bool
+}
+
+func (c *Checker) checkArguments(sig *signature, token scanner.Token, arguments callArguments) {
+	count := len(arguments.positional)
+	required := len(sig.parameters) - sig.optional
+
+	if !arguments.spread && len(arguments.named) == 0 {
+		switch {
+		case required == len(sig.parameters) && !sig.variadic && count != required:
+			c.newError(token, fmt.Sprintf("Expected %d arguments, but got %d.", required, count))
+			return
+		case count < required:
+			c.newError(token, fmt.Sprintf("Expected at least %d arguments, but got %d.", required, count))
+			return
+		case !sig.variadic && count > len(sig.parameters):
+			c.newError(token, fmt.Sprintf("Expected at most %d arguments, but got %d.", len(sig.parameters), count))
+			return
+		}
+	}
+
+	for idx, argument := range arguments.positional {
+		if idx < len(sig.parameters) && !isAssignable(sig.parameters[idx], argument) {
+			c.newError(token, fmt.Sprintf("Argument %d of '%s' expects %s, got %s.", idx+1, sig.name, sig.parameters[idx], argument))
+		}
+	}
+
+	if sig.names == nil {
+		return
+	}
+
+	for idx, named := range arguments.named {
+		position := slices.Index(sig.names, named.Name.Lexeme)
+		if position == -1 {
+			c.newError(named.Name, fmt.Sprintf("Unknown parameter '%s'.", named.Name.Lexeme))
+			continue
+		}
+
+		if !isAssignable(sig.parameters[position], arguments.namedTypes[idx]) {
+			c.newError(named.Name, fmt.Sprintf("Argument '%s' of '%s' expects %s, got %s.", named.Name.Lexeme, sig.name, sig.parameters[position], arguments.namedTypes[idx]))
+		}
+	}
+}
+
+func (c *Checker) arithmeticResult(left *loxType, right *loxType) *loxType {
+	switch {
+	case left.kind == kindDecimal || right.kind == kindDecimal:
+		return decimalType
+	case left.kind == kindBigInt && right.kind == kindBigInt:
+		return bigIntType
+	case left.kind == kindNumber && right.kind == kindNumber:
+		return numberType
+	}
+
+	return anyType
+}
+
+func (c *Checker) VisitArrayExpr(expr parser.ArrayExpr) (any, error) {
+	for _, element := range expr.Elements {
+		c.checkExpr(element)
+	}
+
+	return arrayType, nil
+}
+
+func (c *Checker) VisitRangeExpr(expr parser.RangeExpr) (any, error) {
+	for _, operand := range []parser.Expr{expr.Start, expr.End, expr.Step} {
+		if operand == nil {
+			continue
+		}
+
+		if value := c.checkExpr(operand); !value.isAny() && value.kind != kindNumber {
+			c.newError(expr.Operator, fmt.Sprintf("Range bounds and step should be numbers, got %s.", value))
+		}
+	}
+
+	return rangeType, nil
+}
+
+func (c *Checker) VisitComprehensionExpr(expr parser.ComprehensionExpr) (any, error) {
+	iterable := c.checkExpr(expr.Iterable)
+	if !iterable.isAny() && !iterable.isArrayLike() {
+		c.newError(expr.Name, fmt.Sprintf("Only arrays, ranges and enums can be iterated, got %s.", iterable))
+	}
+
+	c.beginScope()
+	defer c.endScope()
+
+	element := anyType
+	if iterable.kind == kindRange {
+		element = numberType
+	}
+	c.define(expr.Name.Lexeme, element)
+
+	if expr.Condition != nil {
+		c.checkExpr(expr.Condition)
+	}
+	c.checkExpr(expr.Element)
+
+	return arrayType, nil
+}
+
+func (c *Checker) VisitSpreadExpr(expr parser.SpreadExpr) (any, error) {
+	c.checkSpreadable(expr)
+	return anyType, nil
+}
+
+func (c *Checker) checkSpreadable(expr parser.SpreadExpr) {
+	value := c.checkExpr(expr.Expr)
+	if !value.isAny() && !value.isArrayLike() {
+		c.newError(expr.Ellipsis, fmt.Sprintf("Only arrays can be spread, got %s.", value))
+	}
+}
+
+func (c *Checker) VisitDestructureAssignmentExpr(expr parser.DestructureAssignmentExpr) (any, error) {
+	value := c.checkExpr(expr.Value)
+	if !value.isAny() && value.kind != kindArray {
+		c.newError(expr.Bracket, fmt.Sprintf("Only arrays can be destructured with '[]', got %s.", value))
+	}
+
+	for _, target := range expr.Targets {
+		c.checkExpr(target)
+	}
+	if expr.Rest != nil {
+		c.checkExpr(expr.Rest)
+	}
+
+	return value, nil
+}
+
+func (c *Checker) VisitTernaryExpr(expr parser.TernaryExpr) (any, error) {
+	c.checkExpr(expr.Condition)
+
+	return sameType(c.checkExpr(expr.Left), c.checkExpr(expr.Right)), nil
+}
+
+func (c *Checker) VisitAssignmentExpr(expr parser.AssignmentExpr) (any, error) {
+	value := c.checkExpr(expr.Value)
+	target := c.lookup(expr.Name.Lexeme)
+
+	if !isAssignable(target, value) {
+		c.newError(expr.Name, fmt.Sprintf("Can't assign %s to variable '%s' of type %s.", value, expr.Name.Lexeme, target))
+	}
+
+	return value, nil
+}
+
+func (c *Checker) VisitLogicalExpr(expr parser.LogicalExpr) (any, error) {
+	left, right := c.checkExpr(expr.Left), c.checkExpr(expr.Right)
+
+	if expr.Operator.Type == scanner.QUESTION_QUESTION {
+		if left.kind == kindNil {
+			return right, nil
+		}
+		return sameType(left.nonNullable(), right), nil
+	}
+
+	return sameType(left, right), nil
+}
+
+// VisitOptionalChainExpr types a chain like "a?.b" as nullable, since an optional link may stop it.
+func (c *Checker) VisitOptionalChainExpr(expr parser.OptionalChainExpr) (any, error) {
+	return c.checkExpr(expr.Expr).orNil(), nil
+}
+
+func (c *Checker) VisitSetExpr(expr parser.SetExpr) (any, error) {
+	object := c.checkExpr(expr.Object)
+	value := c.checkExpr(expr.Value)
+
+	if object.kind != kindAny && object.kind != kindInstance && object.kind != kindClass {
+		c.newError(expr.Name, fmt.Sprintf("Only instances have properties, got %s.", object))
+	}
+
+	if object.class != nil {
+		static := object.kind == kindClass
+		if setter, ok := object.class.findSetter(expr.Name.Lexeme, static); ok {
+			if !isAssignable(setter, value) {
+				c.newError(expr.Name, fmt.Sprintf("Can't assign %s to property '%s' of type %s.", value, expr.Name.Lexeme, setter))
+			}
+		} else if field, ok := object.class.findField(expr.Name.Lexeme, static); ok && !isAssignable(field, value) {
+			c.newError(expr.Name, fmt.Sprintf("Can't assign %s to field '%s' of type %s.", value, expr.Name.Lexeme, field))
+		}
+	}
+
+	return value, nil
+}
+
+func (c *Checker) VisitArraySetExpr(expr parser.ArraySetExpr) (any, error) {
+	c.checkIndex(c.checkExpr(expr.Array), expr.Index, expr.Bracket, "__setindex__")
+
+	return c.checkExpr(expr.Value), nil
+}
+
+func (c *Checker) checkIndex(array *loxType, indexExpr parser.Expr, bracket scanner.Token, specialMethod string) *loxType {
+	index := c.checkExpr(indexExpr)
+
+	if result, ok := c.specialMethodResult(array, specialMethod); ok {
+		return result
+	}
+
+	if !array.isAny() && !array.isArrayLike() {
+		c.newError(bracket, fmt.Sprintf("Only arrays can be indexed, got %s.", array))
+	}
+
+	if !index.isAny() && index.kind != kindNumber {
+		c.newError(bracket, fmt.Sprintf("Array indices should be numbers, got %s.", index))
+	}
+
+	return anyType
+}
+
+func (c *Checker) VisitSuperExpr(_ parser.SuperExpr) (any, error) {
+	return anyType, nil
+}
+
+func (c *Checker) VisitBinaryExpr(expr parser.BinaryExpr) (any, error) {
+	left := c.checkExpr(expr.Left)
+	right := c.checkExpr(expr.Right)
+	known := !left.isAny() && !right.isAny()
+
+	if expr.Operator.Type == scanner.IS {
+		if !right.isAny() && right.kind != kindClass && right.kind != kindTrait {
+			c.newError(expr.Operator, fmt.Sprintf("Right operand of 'is' should be a class, a trait or an enum, got %s.", right))
+		}
+		return boolType, nil
+	}
+
+	if result, ok := c.specialMethodResult(left, operatorMethods[expr.Operator.Type]); ok {
+		return result, nil
+	}
+	if result, ok := c.specialMethodResult(right, reflectedOperatorMethods[expr.Operator.Type]); ok {
+		return result, nil
+	}
+
+	switch expr.Operator.Type {
+	case scanner.EQUAL_EQUAL, scanner.BANG_EQUAL:
+		return boolType, nil
+	case scanner.PLUS:
+		if left.kind == kindString && right.kind == kindString {
+			return stringType, nil
+		}
+		if known && !(left.isNumeric() && right.isNumeric()) {
+			c.newError(expr.Operator, fmt.Sprintf("Both operands should be numbers or strings, got %s and %s.", left, right))
+		}
+		return c.arithmeticResult(left, right), nil
+	}
+
+	if known && !(left.isNumeric() && right.isNumeric()) {
+		c.newError(expr.Operator, fmt.Sprintf("Both operands should be numbers, got %s and %s.", left, right))
+	}
+
+	switch expr.Operator.Type {
+	case scanner.GREATER, scanner.GREATER_EQUAL, scanner.LESS, scanner.LESS_EQUAL:
+		return boolType, nil
+	}
+
+	return c.arithmeticResult(left, right), nil
+}
+
+func (c *Checker) VisitGroupingExpr(expr parser.GroupingExpr) (any, error) {
+	return c.checkExpr(expr.Expr), nil
+}
+
+func (c *Checker) VisitLiteralExpr(expr parser.LiteralExpr) (any, error) {
+	switch expr.Value.(type) {
+	case nil:
+		return nilType, nil
+	case float64:
+		return numberType, nil
+	case string:
+		return stringType, nil
+	case bool:
+		return boolType, nil
+	case scanner.BigIntLiteral:
+		return bigIntType, nil
+	case scanner.DecimalLiteral:
+		return decimalType, nil
+	}
+
+	return anyType, nil
+}
+
+func (c *Checker) VisitUnaryExpr(expr parser.UnaryExpr) (any, error) {
+	operand := c.checkExpr(expr.Right)
+
+	if expr.Operator.Type == scanner.BANG {
+		return boolType, nil
+	}
+
+	if result, ok := c.specialMethodResult(operand, "__neg__"); ok {
+		return result, nil
+	}
+
+	if !operand.isAny() && !operand.isNumeric() {
+		c.newError(expr.Operator, fmt.Sprintf("Operand must be a number, got %s.", operand))
+		return anyType, nil
+	}
+
+	return operand, nil
+}
+
+func (c *Checker) VisitGetExpr(expr parser.GetExpr) (any, error) {
+	object := c.checkExpr(expr.Object)
+	name := expr.Name.Lexeme
+
+	if expr.Optional && object.kind == kindNil {
+		return anyType, nil
+	}
+
+	switch object.kind {
+	case kindAny:
+		return anyType, nil
+	case kindInstance:
+		if property, ok := object.class.findProperty(name); ok {
+			return property, nil
+		}
+	case kindClass:
+		if object.class == nil {
+			return anyType, nil
+		}
+		if property, ok := object.class.findStaticProperty(name); ok {
+			return property, nil
+		}
+	default:
+		c.newError(expr.Name, fmt.Sprintf("Only instances have properties, got %s.", object))
+		return anyType, nil
+	}
+
+	if !c.assignedProperties[name] {
+		c.newError(expr.Name, fmt.Sprintf("Undefined property '%s' on %s.", name, object))
+	}
+
+	return anyType, nil
+}
+
+func (c *Checker) VisitArrayGetExpr(expr parser.ArrayGetExpr) (any, error) {
+	array := c.checkExpr(expr.Array)
+	if expr.Optional && array.kind == kindNil {
+		c.checkExpr(expr.Index)
+		return anyType, nil
+	}
+
+	return c.checkIndex(array, expr.Index, expr.Bracket, "__index__"), nil
+}
+
+func (c *Checker) VisitCallExpr(expr parser.CallExpr) (any, error) {
+	callee := c.checkExpr(expr.Callee)
+	if expr.Optional && callee.kind == kindNil {
+		callee = anyType
+	}
+
+	arguments := callArguments{positional: make([]*loxType, 0, len(expr.Arguments)), named: expr.NamedArguments}
+	for _, argument := range expr.Arguments {
+		if spread, ok := argument.(parser.SpreadExpr); ok {
+			c.checkSpreadable(spread)
+			arguments.spread = true
+			continue
+		}
+
+		argumentType := c.checkExpr(argument)
+		if !arguments.spread {
+			arguments.positional = append(arguments.positional, argumentType)
+		}
+	}
+
+	for _, argument := range expr.NamedArguments {
+		arguments.namedTypes = append(arguments.namedTypes, c.checkExpr(argument.Value))
+	}
+
+	return c.checkCall(callee, expr.Parenthesis, arguments), nil
+}
+
+func (c *Checker) VisitLambdaExpr(expr parser.LambdaExpr) (any, error) {
+	sig := c.functionSignature("lambda", expr.Parameters, expr.ReturnType)
+	c.checkFunction(sig, expr.Parameters, expr.Body)
+
+	return newFunctionType(sig), nil
+}
+
+func (c *Checker) VisitThisExpr(_ parser.ThisExpr) (any, error) {
+	if c.currentClass == nil {
+		return anyType, nil
+	}
+
+	return newInstanceType(c.currentClass), nil
+}
+
+func (c *Checker) VisitVariableExpr(expr parser.VariableExpr) (any, error) {
+	return c.lookup(expr.Name.Lexeme), nil
+}
+
+func (c *Checker) VisitMatchExpr(expr parser.MatchExpr) (any, error) {
+	c.checkExpr(expr.Subject)
+
+	var result *loxType
+	for _, matchCase := range expr.Cases {
+		c.beginScope()
+		c.checkPattern(matchCase.Pattern)
+
+		if matchCase.Guard != nil {
+			c.checkExpr(matchCase.Guard)
+		}
+
+		caseType := nilType
+		if matchCase.Block != nil {
+			c.VisitBlockStmt(parser.BlockStmt{Declarations: matchCase.Block})
+		} else {
+			caseType = c.checkExpr(matchCase.Body)
+		}
+		c.endScope()
+
+		if result == nil {
+			result = caseType
+		} else {
+			result = sameType(result, caseType)
+		}
+	}
+
+	if result == nil {
+		return anyType, nil
+	}
+
+	return result, nil
+}
+
+func (c *Checker) checkPattern(pattern parser.Pattern) {
+	switch pattern := pattern.(type) {
+	case parser.BindingPattern:
+		c.define(pattern.Name.Lexeme, anyType)
+	case parser.ValuePattern:
+		c.checkExpr(pattern.Value)
+	case parser.ArrayPattern:
+		for _, element := range pattern.Elements {
+			c.checkPattern(element)
+		}
+		if pattern.Rest != nil {
+			c.define(pattern.Rest.Lexeme, arrayType)
+		}
+	case parser.ObjectPattern:
+		for _, field := range pattern.Fields {
+			c.checkPattern(field.Pattern)
+		}
+	case parser.ClassPattern:
+		class := c.checkExpr(pattern.Class)
+		if !class.isAny() && class.kind != kindClass {
+			c.newError(pattern.Parenthesis, fmt.Sprintf("Class patterns can only match classes, got %s.", class))
+		}
+		for _, argument := range pattern.Arguments {
+			c.checkPattern(argument)
+		}
+	}
+}
+
+func (c *Checker) VisitExpressionStmt(stmt parser.ExpressionStmt) (any, error) {
+	c.checkExpr(stmt.Expression)
+	return nil, nil
+}
+
+func (c *Checker) VisitPrintStmt(stmt parser.PrintStmt) (any, error) {
+	c.checkExpr(stmt.Expression)
+	return nil, nil
+}
+
+func (c *Checker) VisitVarStmt(stmt parser.VarStmt) (any, error) {
+	declared := c.resolveAnnotation(stmt.Type)
+	value := nilType
+
+	if stmt.Initializer != nil {
+		value = c.checkExpr(stmt.Initializer)
+	}
+
+	if stmt.Type != nil {
+		if stmt.Initializer != nil && !isAssignable(declared, value) {
+			c.newError(stmt.Name, fmt.Sprintf("Can't assign %s to variable '%s' of type %s.", value, stmt.Name.Lexeme, declared))
+		}
+		c.define(stmt.Name.Lexeme, declared)
+	} else if stmt.Initializer == nil || c.reassigned[stmt.Name.Lexeme] {
+		c.define(stmt.Name.Lexeme, anyType)
+	} else {
+		c.define(stmt.Name.Lexeme, value)
+	}
+
+	return nil, nil
+}
+
+func (c *Checker) VisitDestructureStmt(stmt parser.DestructureStmt) (any, error) {
+	value := c.checkExpr(stmt.Initializer)
+
+	switch stmt.Pattern.(type) {
+	case parser.ArrayPattern:
+		if !value.isAny() && !value.isArrayLike() {
+			c.newError(stmt.Keyword, fmt.Sprintf("Only arrays can be destructured with '[]', got %s.", value))
+		}
+	case parser.ObjectPattern:
+		if !value.isAny() && value.kind != kindInstance {
+			c.newError(stmt.Keyword, fmt.Sprintf("Only instances can be destructured with '{}', got %s.", value))
+		}
+	}
+
+	c.checkPattern(stmt.Pattern)
+
+	return nil, nil
+}
+
+func (c *Checker) checkMethods(class *classInfo, methods []parser.FunctionStmt, staticMethods []parser.FunctionStmt) {
+	previousClass := c.currentClass
+	c.currentClass = class
+	defer func() {
+		c.currentClass = previousClass
+	}()
+
+	for _, method := range methods {
+		sig := &signature{name: method.Name.Lexeme, parameters: []*loxType{}, returnType: c.resolveAnnotation(method.ReturnType)}
+		if method.Parameters != nil {
+			sig = c.functionSignature(method.Name.Lexeme, method.Parameters, method.ReturnType)
+		}
+		c.checkFunction(sig, method.Parameters, method.Body)
+	}
+
+	c.currentClass = nil
+	for _, method := range staticMethods {
+		sig := &signature{name: method.Name.Lexeme, parameters: []*loxType{}, returnType: c.resolveAnnotation(method.ReturnType)}
+		if method.Parameters != nil {
+			sig = c.functionSignature(method.Name.Lexeme, method.Parameters, method.ReturnType)
+		}
+		c.checkFunction(sig, method.Parameters, method.Body)
+	}
+}
+
+func (c *Checker) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
+	if !reflect.ValueOf(stmt.Superclass).IsZero() {
+		superclass := c.checkExpr(stmt.Superclass)
+		if !superclass.isAny() && superclass.kind != kindClass {
+			c.newError(stmt.Superclass.Name, fmt.Sprintf("Superclass must be a class, got %s.", superclass))
+		}
+	}
+
+	class := c.declareClass(stmt)
+	c.define(stmt.Name.Lexeme, newClassType(class))
+	c.checkMethods(class, stmt.Methods, stmt.StaticMethods)
+	c.checkMethods(class, stmt.Setters, stmt.StaticSetters)
+	c.checkFields(class, stmt.Fields, stmt.StaticFields)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
+	c.declareTrait(stmt)
+
+	// Traits are checked with an unknown host class, since any class may use them.
+	open := newClassInfo(stmt.Name.Lexeme)
+	open.open = true
+	c.checkMethods(open, stmt.Methods, stmt.StaticMethods)
+	c.checkMethods(open, stmt.Setters, stmt.StaticSetters)
+	c.checkFields(open, stmt.Fields, stmt.StaticFields)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitEnumStmt(stmt parser.EnumStmt) (any, error) {
+	for _, variant := range stmt.Variants {
+		if variant.Parameters != nil {
+			sig := c.functionSignature(variant.Name.Lexeme, variant.Parameters, nil)
+			c.checkFunction(sig, variant.Parameters, make([]parser.Stmt, 0))
+		}
+	}
+
+	// Enum values aren't tracked by the checker, so the enum and its methods are checked with
+	// an unknown 'this'.
+	c.define(stmt.Name.Lexeme, anyType)
+	open := newClassInfo(stmt.Name.Lexeme)
+	open.open = true
+	c.checkMethods(open, stmt.Methods, stmt.StaticMethods)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitFunctionStmt(stmt parser.FunctionStmt) (any, error) {
+	sig := c.functionSignature(stmt.Name.Lexeme, stmt.Parameters, stmt.ReturnType)
+	c.define(stmt.Name.Lexeme, newFunctionType(sig))
+	c.checkFunction(sig, stmt.Parameters, stmt.Body)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitBlockStmt(stmt parser.BlockStmt) (any, error) {
+	c.beginScope()
+	defer c.endScope()
+
+	c.checkStmts(stmt.Declarations)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitIfStmt(stmt parser.IfStmt) (any, error) {
+	c.checkExpr(stmt.Expression)
+	c.checkStmt(stmt.ThenBranch)
+
+	if stmt.ElseBranch != nil {
+		c.checkStmt(stmt.ElseBranch)
+	}
+
+	return nil, nil
+}
+
+func (c *Checker) VisitWhileStmt(stmt parser.WhileStmt) (any, error) {
+	c.checkExpr(stmt.Condition)
+	c.checkStmt(stmt.Body)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitDoWhileStmt(stmt parser.DoWhileStmt) (any, error) {
+	c.checkStmt(stmt.Body)
+	c.checkExpr(stmt.Condition)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitSwitchStmt(stmt parser.SwitchStmt) (any, error) {
+	c.checkExpr(stmt.Subject)
+
+	for _, switchCase := range stmt.Cases {
+		for _, value := range switchCase.Values {
+			c.checkExpr(value)
+		}
+		c.checkCaseBody(switchCase.Body)
+	}
+	c.checkCaseBody(stmt.Default)
+
+	return nil, nil
+}
+
+func (c *Checker) checkCaseBody(body []parser.Stmt) {
+	c.beginScope()
+	defer c.endScope()
+
+	c.checkStmts(body)
+}
+
+func (c *Checker) VisitForStmt(stmt parser.ForStmt) (any, error) {
+	if stmt.Initializer != nil {
+		c.checkStmt(stmt.Initializer)
+	}
+
+	if stmt.Condition != nil {
+		c.checkExpr(stmt.Condition)
+	}
+
+	if stmt.Increment != nil {
+		c.checkStmt(stmt.Increment)
+	}
+
+	c.checkStmt(stmt.Body)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitBreakStmt(_ parser.BreakStmt) (any, error) {
+	return nil, nil
+}
+
+func (c *Checker) VisitContinueStmt(_ parser.ContinueStmt) (any, error) {
+	return nil, nil
+}
+
+func (c *Checker) VisitAssertStmt(stmt parser.AssertStmt) (any, error) {
+	c.checkExpr(stmt.Condition)
+	if stmt.Message != nil {
+		c.checkExpr(stmt.Message)
+	}
+
+	return nil, nil
+}
+
+func (c *Checker) VisitDeferStmt(stmt parser.DeferStmt) (any, error) {
+	c.checkExpr(stmt.Expr)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitUsingStmt(stmt parser.UsingStmt) (any, error) {
+	resource := c.checkExpr(stmt.Initializer)
+
+	c.beginScope()
+	defer c.endScope()
+
+	if c.reassigned[stmt.Name.Lexeme] {
+		resource = anyType
+	}
+	c.define(stmt.Name.Lexeme, resource)
+	c.checkStmt(stmt.Body)
+
+	return nil, nil
+}
+
+func (c *Checker) VisitReturnStmt(stmt parser.ReturnStmt) (any, error) {
+	value := nilType
+	if stmt.Expr != nil {
+		value = c.checkExpr(stmt.Expr)
+	}
+
+	if c.currentFunction != nil && !isAssignable(c.currentFunction.returnType, value) {
+		c.newError(stmt.Keyword, fmt.Sprintf("Can't return %s from '%s' declared to return %s.", value, c.currentFunction.name, c.currentFunction.returnType))
+	}
+
+	return nil, nil
+}
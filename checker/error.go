@@ -0,0 +1,15 @@
+package checker
+
+import (
+	"fmt"
+	"glox/scanner"
+)
+
+type Error struct {
+	Token   scanner.Token
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("[line %d] Type error: %s\n", e.Token.Line, e.Message)
+}
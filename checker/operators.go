@@ -0,0 +1,53 @@
+package checker
+
+import "glox/scanner"
+
+var operatorMethods = map[scanner.TokenType]string{
+	scanner.PLUS:          "__add__",
+	scanner.MINUS:         "__sub__",
+	scanner.STAR:          "__mul__",
+	scanner.SLASH:         "__div__",
+	scanner.MODULO:        "__mod__",
+	scanner.LESS:          "__lt__",
+	scanner.LESS_EQUAL:    "__le__",
+	scanner.GREATER:       "__gt__",
+	scanner.GREATER_EQUAL: "__ge__",
+	scanner.EQUAL_EQUAL:   "__eq__",
+	scanner.BANG_EQUAL:    "__eq__",
+}
+
+var reflectedOperatorMethods = map[scanner.TokenType]string{
+	scanner.PLUS:          "__radd__",
+	scanner.MINUS:         "__rsub__",
+	scanner.STAR:          "__rmul__",
+	scanner.SLASH:         "__rdiv__",
+	scanner.MODULO:        "__rmod__",
+	scanner.LESS:          "__gt__",
+	scanner.LESS_EQUAL:    "__ge__",
+	scanner.GREATER:       "__lt__",
+	scanner.GREATER_EQUAL: "__le__",
+	scanner.EQUAL_EQUAL:   "__eq__",
+	scanner.BANG_EQUAL:    "__eq__",
+}
+
+// specialMethodResult returns the result type of calling the special method name on a value of
+// type t. The second result is false when t isn't an instance that may define the method.
+func (c *Checker) specialMethodResult(t *loxType, name string) (*loxType, bool) {
+	if t.kind != kindInstance || name == "" {
+		return nil, false
+	}
+
+	method, ok := t.class.findProperty(name)
+	if !ok {
+		return nil, false
+	}
+
+	if method.kind == kindFunction && method.signature != nil {
+		if name == "__eq__" {
+			return boolType, true
+		}
+		return method.signature.returnType, true
+	}
+
+	return anyType, method.isAny()
+}
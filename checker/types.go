@@ -0,0 +1,279 @@
+package checker
+
+import "fmt"
+
+const (
+	kindAny      = "Any"
+	kindNumber   = "Number"
+	kindString   = "String"
+	kindBool     = "Bool"
+	kindNil      = "Nil"
+	kindArray    = "Array"
+	kindRange    = "Range"
+	kindBigInt   = "BigInt"
+	kindDecimal  = "Decimal"
+	kindFunction = "Function"
+	kindClass    = "Class"
+	kindInstance = "Instance"
+	kindTrait    = "Trait"
+)
+
+var builtinTypes = map[string]string{
+	"Any":      kindAny,
+	"Number":   kindNumber,
+	"String":   kindString,
+	"Bool":     kindBool,
+	"Nil":      kindNil,
+	"Array":    kindArray,
+	"Range":    kindRange,
+	"BigInt":   kindBigInt,
+	"Decimal":  kindDecimal,
+	"Function": kindFunction,
+	"Class":    kindClass,
+}
+
+type loxType struct {
+	kind      string
+	class     *classInfo
+	nullable  bool
+	signature *signature
+}
+
+// signature describes a callable. The last optional parameters have default values, and a
+// variadic callable accepts any number of extra arguments after its parameters.
+type signature struct {
+	name       string
+	parameters []*loxType
+	names      []string
+	optional   int
+	variadic   bool
+	returnType *loxType
+}
+
+var (
+	anyType     = &loxType{kind: kindAny}
+	numberType  = &loxType{kind: kindNumber}
+	stringType  = &loxType{kind: kindString}
+	boolType    = &loxType{kind: kindBool}
+	nilType     = &loxType{kind: kindNil}
+	arrayType   = &loxType{kind: kindArray}
+	rangeType   = &loxType{kind: kindRange}
+	bigIntType  = &loxType{kind: kindBigInt}
+	decimalType = &loxType{kind: kindDecimal}
+	classType   = &loxType{kind: kindClass}
+)
+
+func newFunctionType(signature *signature) *loxType {
+	return &loxType{kind: kindFunction, signature: signature}
+}
+
+func newClassType(class *classInfo) *loxType {
+	return &loxType{kind: kindClass, class: class}
+}
+
+func newInstanceType(class *classInfo) *loxType {
+	return &loxType{kind: kindInstance, class: class}
+}
+
+func (t *loxType) isAny() bool {
+	return t.kind == kindAny
+}
+
+// isArrayLike reports whether the value can be used as an array, which ranges can.
+func (t *loxType) isArrayLike() bool {
+	return t.kind == kindArray || t.kind == kindRange
+}
+
+func (t *loxType) isNumeric() bool {
+	return t.kind == kindNumber || t.kind == kindBigInt || t.kind == kindDecimal
+}
+
+func (t *loxType) String() string {
+	name := t.kind
+
+	switch t.kind {
+	case kindInstance:
+		name = t.class.name
+	case kindClass:
+		if t.class != nil {
+			name = fmt.Sprintf("class %s", t.class.name)
+		}
+	}
+
+	if t.nullable {
+		return name + "?"
+	}
+
+	return name
+}
+
+// nonNullable returns the type without nil, as in the result of "value ?? fallback".
+func (t *loxType) nonNullable() *loxType {
+	if !t.nullable {
+		return t
+	}
+
+	copied := *t
+	copied.nullable = false
+	return &copied
+}
+
+// orNil returns the type that also allows nil. Any and Nil already do.
+func (t *loxType) orNil() *loxType {
+	if t.nullable || t.isAny() || t.kind == kindNil {
+		return t
+	}
+
+	copied := *t
+	copied.nullable = true
+	return &copied
+}
+
+// isAssignable reports whether a value of type value can be stored where target is expected.
+// Any is compatible with every type in both directions, which makes the checking gradual.
+func isAssignable(target *loxType, value *loxType) bool {
+	if target.isAny() || value.isAny() {
+		return true
+	}
+
+	if value.kind == kindNil {
+		return target.nullable || target.kind == kindNil
+	}
+
+	// Ranges are turned into arrays wherever an array is expected.
+	if target.kind == kindArray && value.kind == kindRange {
+		return true
+	}
+
+	if target.kind != value.kind {
+		return false
+	}
+
+	switch target.kind {
+	case kindInstance:
+		return value.class.inherits(target.class)
+	case kindClass:
+		return target.class == nil || value.class.inherits(target.class)
+	}
+
+	return true
+}
+
+// sameType returns the type shared by both operands, or Any when they differ.
+func sameType(left *loxType, right *loxType) *loxType {
+	if left.kind == right.kind && left.class == right.class {
+		return left
+	}
+
+	return anyType
+}
+
+type classInfo struct {
+	name          string
+	superclass    *classInfo
+	open          bool
+	declared      bool
+	methods       map[string]*signature
+	getters       map[string]*loxType
+	staticMethods map[string]*signature
+	staticGetters map[string]*loxType
+	setters       map[string]*loxType
+	staticSetters map[string]*loxType
+	fields        map[string]*loxType
+	staticFields  map[string]*loxType
+}
+
+func newClassInfo(name string) *classInfo {
+	return &classInfo{
+		name:          name,
+		methods:       make(map[string]*signature),
+		getters:       make(map[string]*loxType),
+		staticMethods: make(map[string]*signature),
+		staticGetters: make(map[string]*loxType),
+		setters:       make(map[string]*loxType),
+		staticSetters: make(map[string]*loxType),
+		fields:        make(map[string]*loxType),
+		staticFields:  make(map[string]*loxType),
+	}
+}
+
+func (c *classInfo) inherits(class *classInfo) bool {
+	for current := c; current != nil; current = current.superclass {
+		if current == class || current.open {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findProperty looks up an instance member. The second result is false when the member is
+// unknown and the class hierarchy is fully known, so the property certainly doesn't exist.
+func (c *classInfo) findProperty(name string) (*loxType, bool) {
+	for current := c; current != nil; current = current.superclass {
+		if field, ok := current.fields[name]; ok {
+			return field, true
+		}
+		if method, ok := current.methods[name]; ok {
+			return newFunctionType(method), true
+		}
+		if getter, ok := current.getters[name]; ok {
+			return getter, true
+		}
+		if current.open {
+			return anyType, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *classInfo) findStaticProperty(name string) (*loxType, bool) {
+	for current := c; current != nil; current = current.superclass {
+		if field, ok := current.staticFields[name]; ok {
+			return field, true
+		}
+		if method, ok := current.staticMethods[name]; ok {
+			return newFunctionType(method), true
+		}
+		if getter, ok := current.staticGetters[name]; ok {
+			return getter, true
+		}
+		if current.open {
+			return anyType, true
+		}
+	}
+
+	return nil, false
+}
+
+// findSetter returns the type of value accepted by the setter of an instance or static property.
+func (c *classInfo) findSetter(name string, static bool) (*loxType, bool) {
+	for current := c; current != nil; current = current.superclass {
+		setters := current.setters
+		if static {
+			setters = current.staticSetters
+		}
+
+		if setter, ok := setters[name]; ok {
+			return setter, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *classInfo) findField(name string, static bool) (*loxType, bool) {
+	for current := c; current != nil; current = current.superclass {
+		fields := current.fields
+		if static {
+			fields = current.staticFields
+		}
+
+		if field, ok := fields[name]; ok {
+			return field, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,35 @@
+package checker
+
+import (
+	"glox/scanner"
+	"reflect"
+)
+
+var tokenType = reflect.TypeOf(scanner.Token{})
+
+// walk calls visit for every AST node reachable from node, in source order.
+func walk(node any, visit func(node any)) {
+	walkValue(reflect.ValueOf(node), visit)
+}
+
+func walkValue(value reflect.Value, visit func(node any)) {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !value.IsNil() {
+			walkValue(value.Elem(), visit)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			walkValue(value.Index(i), visit)
+		}
+	case reflect.Struct:
+		if value.Type() == tokenType {
+			return
+		}
+
+		visit(value.Interface())
+		for i := 0; i < value.NumField(); i++ {
+			walkValue(value.Field(i), visit)
+		}
+	}
+}
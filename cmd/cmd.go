@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"glox/checker"
 	"glox/interpreter"
 	"glox/parser"
 	"glox/resolver"
@@ -25,13 +26,13 @@ func printWarnings(warnings ...error) {
 	}
 }
 
-func run(source string) int {
+func compile(source string, _interpreter *interpreter.Interpreter) ([]parser.Stmt, int) {
 	_scanner := scanner.New(source)
 	tokens, err := _scanner.Run()
 
 	if err != nil {
 		printErrors(err)
-		return 63
+		return nil, 63
 	}
 
 	_parser := parser.New(tokens)
@@ -39,17 +40,26 @@ func run(source string) int {
 
 	if len(errs) != 0 {
 		printErrors(errs...)
-		return 65
+		return nil, 65
 	}
 
 	_resolver := resolver.New(_interpreter)
 	if _, err := _resolver.Resolve(statements); err != nil {
 		printErrors(err)
-		return 67
+		return nil, 67
 	}
 
 	printWarnings(_resolver.Warnings()...)
 
+	return statements, 0
+}
+
+func run(source string) int {
+	statements, exitCode := compile(source, _interpreter)
+	if exitCode != 0 {
+		return exitCode
+	}
+
 	if err := _interpreter.Interpret(statements); err != nil {
 		printErrors(err)
 		return 70
@@ -58,6 +68,13 @@ func run(source string) int {
 	return 0
 }
 
+// Execute runs source with a fresh interpreter as "glox script.glox" would, and returns its exit
+// code: 63 for scanner errors, 65 for parser errors, 67 for resolver errors and 70 for runtime errors.
+func Execute(source string) int {
+	_interpreter = interpreter.New()
+	return run(source)
+}
+
 func runExpr(source string) {
 	_scanner := scanner.New(source)
 	tokens, err := _scanner.Run()
@@ -82,10 +99,30 @@ func runExpr(source string) {
 		return
 	}
 
-	fmt.Println(_interpreter.Stringify(result))
+	text, err := _interpreter.Stringify(result)
+	if err != nil {
+		printErrors(err)
+		return
+	}
+
+	fmt.Println(text)
 }
 
-func runFile(filePath string) {
+func check(source string) int {
+	statements, exitCode := compile(source, interpreter.New())
+	if exitCode != 0 {
+		return exitCode
+	}
+
+	if errs := checker.New().Check(statements); len(errs) != 0 {
+		printErrors(errs...)
+		return 65
+	}
+
+	return 0
+}
+
+func readSource(filePath string) string {
 	source, err := os.ReadFile(filePath)
 	if err != nil {
 		fmt.Println(err)
@@ -97,7 +134,16 @@ func runFile(filePath string) {
 		os.Exit(1)
 	}
 
-	exitCode := run(string(source))
+	return string(source)
+}
+
+func runFile(filePath string) {
+	exitCode := run(readSource(filePath))
+	os.Exit(exitCode)
+}
+
+func checkFile(filePath string) {
+	exitCode := check(readSource(filePath))
 	os.Exit(exitCode)
 }
 
@@ -127,10 +173,16 @@ func Run(args []string) {
 	_interpreter = interpreter.New()
 	if len(args) == 0 {
 		repl()
+	} else if args[0] == "test" {
+		os.Exit(RunTests(args[1:]))
+	} else if args[0] == "profile" {
+		os.Exit(Profile(args[1:]))
 	} else if len(args) == 1 {
 		runFile(args[0])
+	} else if len(args) == 2 && args[0] == "check" {
+		checkFile(args[1])
 	} else {
-		fmt.Println("Usage: glox [script]")
+		fmt.Println("Usage: glox [script] | glox check [script] | glox test [-run pattern] [-cover] [-coverprofile file] [-coverhtml file] [path...] | glox profile [-pprof file] [-folded file] [script]")
 		os.Exit(64)
 	}
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"glox/interpreter"
+	"glox/profile"
+	"io"
+	"os"
+)
+
+// Profile implements "glox profile": it runs a script like "glox script.glox" does, prints where
+// the script spent its time, and returns the exit code of the run.
+func Profile(args []string) int {
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	pprofPath := flags.String("pprof", "", "write the profile to this file in the pprof format")
+	foldedPath := flags.String("folded", "", "write the profile to this file as folded stacks for flame graphs")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		fmt.Println("Usage: glox profile [-pprof file] [-folded file] script")
+		return 64
+	}
+
+	path := flags.Arg(0)
+	source := readSource(path)
+
+	_interpreter = interpreter.New()
+	statements, exitCode := compile(source, _interpreter)
+	if exitCode != 0 {
+		return exitCode
+	}
+
+	profiler := profile.New(path, source)
+	_interpreter.SetTracer(profiler)
+
+	if err := _interpreter.Interpret(statements); err != nil {
+		printErrors(err)
+		exitCode = 70
+	}
+	profiler.Stop()
+
+	fmt.Println()
+	if err := profile.WriteReport(os.Stdout, profiler); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
+	exports := []struct {
+		path  string
+		write func(io.Writer, *profile.Profiler) error
+	}{
+		{*pprofPath, profile.WritePprof},
+		{*foldedPath, profile.WriteFolded},
+	}
+
+	for _, export := range exports {
+		if export.path == "" {
+			continue
+		}
+
+		if err := writeProfile(export.path, profiler, export.write); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+	}
+
+	return exitCode
+}
+
+func writeProfile(path string, profiler *profile.Profiler, write func(io.Writer, *profile.Profiler) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file, profiler); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"glox/coverage"
+	"glox/interpreter"
+	"glox/parser"
+	"glox/resolver"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// testSummary counts the tests of a run, or of a single file.
+type testSummary struct {
+	passed int
+	failed int
+}
+
+// RunTests implements "glox test": it runs the test functions of every *_test.glox file under
+// the given paths and returns the exit code of the run.
+func RunTests(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	pattern := flags.String("run", "", "only run the tests whose name matches this regular expression")
+	cover := flags.Bool("cover", false, "print the statement and branch coverage of the test files")
+	coverProfile := flags.String("coverprofile", "", "write the coverage to this file in the LCOV format")
+	coverHTML := flags.String("coverhtml", "", "write the coverage to this file as an HTML report")
+	if err := flags.Parse(args); err != nil {
+		return 64
+	}
+
+	filter, err := regexp.Compile(*pattern)
+	if err != nil {
+		fmt.Printf("Invalid -run pattern: %s\n", err)
+		return 64
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files, err := findTestFiles(paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if len(files) == 0 {
+		fmt.Println("No test files found.")
+		return 0
+	}
+
+	var profiles []*coverage.Profile
+	if *cover || *coverProfile != "" || *coverHTML != "" {
+		profiles = make([]*coverage.Profile, 0, len(files))
+	}
+
+	start := time.Now()
+	total := testSummary{}
+	for _, file := range files {
+		summary, profile := runTestFile(file, filter, profiles != nil)
+		total.passed += summary.passed
+		total.failed += summary.failed
+
+		if profile != nil {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	if profiles != nil {
+		if err := writeCoverage(profiles, *coverProfile, *coverHTML); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+	}
+
+	elapsed := formatDuration(time.Since(start))
+	if total.failed != 0 {
+		fmt.Printf("FAIL: %d passed, %d failed in %s\n", total.passed, total.failed, elapsed)
+		return 1
+	}
+
+	fmt.Printf("PASS: %d passed in %s\n", total.passed, elapsed)
+	return 0
+}
+
+// findTestFiles returns the *_test.glox files among paths and in the directories under them.
+func findTestFiles(paths []string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.IsDir() && strings.HasSuffix(path, "_test.glox") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// runTestFile runs every top-level function of the file whose name starts with "test" and
+// matches filter. Each test gets a fresh interpreter that runs the top-level code of the file
+// before calling the test function, so tests can't affect each other. With cover, it also returns
+// the coverage of the file by all its tests.
+func runTestFile(path string, filter *regexp.Regexp, cover bool) (testSummary, *coverage.Profile) {
+	summary := testSummary{}
+	start := time.Now()
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Println(err)
+		summary.failed++
+		return summary, nil
+	}
+
+	statements, exitCode := compile(string(source), interpreter.New())
+	if exitCode != 0 {
+		fmt.Printf("FAIL\t%s\tcompilation failed\n", path)
+		summary.failed++
+		return summary, nil
+	}
+
+	var profile *coverage.Profile
+	if cover {
+		profile = coverage.New(path, string(source), statements)
+	}
+
+	for _, function := range testFunctions(statements, filter) {
+		name := function.Name.Lexeme
+		testStart := time.Now()
+		err := runTest(statements, function, profile)
+		elapsed := formatDuration(time.Since(testStart))
+
+		if err != nil {
+			fmt.Printf("--- FAIL: %s (%s)\n", name, elapsed)
+			fmt.Printf("    %s: %s", path, err.Error())
+			summary.failed++
+		} else {
+			fmt.Printf("--- PASS: %s (%s)\n", name, elapsed)
+			summary.passed++
+		}
+	}
+
+	status := "ok"
+	if summary.failed != 0 {
+		status = "FAIL"
+	}
+	fmt.Printf("%s\t%s\t%d passed, %d failed\t%s\n", status, path, summary.passed, summary.failed, formatDuration(time.Since(start)))
+
+	return summary, profile
+}
+
+func testFunctions(statements []parser.Stmt, filter *regexp.Regexp) []parser.FunctionStmt {
+	functions := make([]parser.FunctionStmt, 0)
+
+	for _, stmt := range statements {
+		function, ok := stmt.(parser.FunctionStmt)
+		if !ok {
+			continue
+		}
+
+		name := function.Name.Lexeme
+		if strings.HasPrefix(name, "test") && filter.MatchString(name) {
+			functions = append(functions, function)
+		}
+	}
+
+	return functions
+}
+
+// runTest runs a test function, which fails when it has parameters without a default value, as
+// tests are called without arguments.
+func runTest(statements []parser.Stmt, function parser.FunctionStmt, profile *coverage.Profile) error {
+	for _, parameter := range function.Parameters {
+		if parameter.Default == nil && !parameter.Rest {
+			return &interpreter.Error{Token: function.Name, Message: "Test functions can't have required parameters."}
+		}
+	}
+
+	_interpreter := interpreter.New()
+	if profile != nil {
+		_interpreter.SetTracer(profile)
+	}
+
+	if _, err := resolver.New(_interpreter).Resolve(statements); err != nil {
+		return err
+	}
+
+	if err := _interpreter.Interpret(statements); err != nil {
+		return err
+	}
+
+	_, err := _interpreter.CallGlobal(function.Name.Lexeme)
+	return err
+}
+
+// writeCoverage prints the coverage summary, and writes the LCOV and HTML reports to the files
+// they were requested in.
+func writeCoverage(profiles []*coverage.Profile, lcovPath string, htmlPath string) error {
+	fmt.Println()
+	if err := coverage.WriteSummary(os.Stdout, profiles); err != nil {
+		return err
+	}
+
+	reports := []struct {
+		path  string
+		write func(io.Writer, []*coverage.Profile) error
+	}{
+		{lcovPath, coverage.WriteLCOV},
+		{htmlPath, coverage.WriteHTML},
+	}
+
+	for _, report := range reports {
+		if report.path == "" {
+			continue
+		}
+
+		file, err := os.Create(report.path)
+		if err != nil {
+			return err
+		}
+		if err := report.write(file, profiles); err != nil {
+			_ = file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Microsecond).String()
+}
@@ -0,0 +1,153 @@
+// Package coverage measures which statements and branches of a program run, and reports it as
+// a summary table, an HTML page with the annotated source, or an LCOV file.
+package coverage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"glox/parser"
+	"reflect"
+	"strings"
+)
+
+// Profile is the coverage of a single source file. It implements interpreter.Tracer, so it can be
+// shared by all the interpreters running the file.
+type Profile struct {
+	Path  string
+	lines []string
+	// statements counts how many times the statements starting on each line ran.
+	statements map[int32]int
+	branches   map[string]*branch
+	// order holds the branches in the order they appear in the source.
+	order []*branch
+}
+
+// branch counts how many times each arm of an if statement, a ternary or a logical expression
+// was picked.
+type branch struct {
+	line  int32
+	kind  string
+	taken [2]int
+}
+
+func (b *branch) evaluated() bool {
+	return b.taken[0]+b.taken[1] > 0
+}
+
+// New creates the profile of a parsed file, with every statement and branch of it not covered yet.
+func New(path string, source string, statements []parser.Stmt) *Profile {
+	profile := &Profile{
+		Path:       path,
+		lines:      strings.Split(source, "\n"),
+		statements: make(map[int32]int),
+		branches:   make(map[string]*branch),
+		order:      make([]*branch, 0),
+	}
+	profile.walk(reflect.ValueOf(statements))
+
+	return profile
+}
+
+func (p *Profile) Statement(stmt parser.Stmt) {
+	if line := parser.StmtLine(stmt); line > 0 {
+		p.statements[line]++
+	}
+}
+
+func (p *Profile) Branch(node any, first bool) {
+	key, ok := p.branchOf(node)
+	if !ok {
+		return
+	}
+
+	if first {
+		key.taken[0]++
+	} else {
+		key.taken[1]++
+	}
+}
+
+// Call is ignored, as coverage doesn't depend on how statements were reached.
+func (p *Profile) Call(_ string, _ int32) {
+}
+
+// Return is ignored like Call.
+func (p *Profile) Return() {
+}
+
+var stmtType = reflect.TypeOf((*parser.Stmt)(nil)).Elem()
+
+// walk registers the statements and branches under value. Only values in the place of a
+// statement count as statements: the methods and fields of a class never run as one.
+func (p *Profile) walk(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if value.IsNil() {
+			return
+		}
+
+		if value.Type() == stmtType {
+			if line := parser.StmtLine(value.Interface().(parser.Stmt)); line > 0 {
+				p.statements[line] += 0
+			}
+		}
+		p.walk(value.Elem())
+	case reflect.Slice:
+		for idx := 0; idx < value.Len(); idx++ {
+			p.walk(value.Index(idx))
+		}
+	case reflect.Struct:
+		// Tokens and literal values can't hold any statement.
+		if value.Type().PkgPath() != stmtType.PkgPath() {
+			return
+		}
+
+		p.branchOf(value.Interface())
+		for idx := 0; idx < value.NumField(); idx++ {
+			p.walk(value.Field(idx))
+		}
+	}
+}
+
+// branchOf returns the counts of node, registering them the first time, if node is a branch.
+// Branches are told apart by their line and condition, so identical conditions on the same line
+// share their counts.
+func (p *Profile) branchOf(node any) (*branch, bool) {
+	var line int32
+	var kind string
+	var condition parser.Expr
+
+	switch node := node.(type) {
+	case parser.IfStmt:
+		line, kind, condition = node.Keyword.Line, "if", node.Expression
+	case parser.TernaryExpr:
+		line, kind, condition = node.Question.Line, "ternary", node.Condition
+	case parser.LogicalExpr:
+		line, kind, condition = node.Operator.Line, node.Operator.Lexeme, node
+	default:
+		return nil, false
+	}
+
+	key := fmt.Sprintf("%d:%s:%s", line, kind, encode(condition))
+	if existing, ok := p.branches[key]; ok {
+		return existing, true
+	}
+
+	registered := &branch{line: line, kind: kind}
+	p.branches[key] = registered
+	p.order = append(p.order, registered)
+
+	return registered, true
+}
+
+func encode(expr parser.Expr) string {
+	serializedData, err := json.Marshal(expr)
+	if err != nil {
+		panic("Could not encode expression.")
+	}
+
+	hash := md5.Sum(serializedData)
+	return hex.EncodeToString(hash[:])
+}
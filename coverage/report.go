@@ -0,0 +1,204 @@
+package coverage
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// stats counts what was covered out of what could be.
+type stats struct {
+	covered int
+	total   int
+}
+
+func (s stats) String() string {
+	if s.total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d/%d %5.1f%%", s.covered, s.total, 100*float64(s.covered)/float64(s.total))
+}
+
+func (s stats) add(other stats) stats {
+	return stats{covered: s.covered + other.covered, total: s.total + other.total}
+}
+
+func (p *Profile) statementStats() stats {
+	result := stats{total: len(p.statements)}
+	for _, count := range p.statements {
+		if count > 0 {
+			result.covered++
+		}
+	}
+
+	return result
+}
+
+// branchStats counts each arm of a branch on its own.
+func (p *Profile) branchStats() stats {
+	result := stats{total: 2 * len(p.order)}
+	for _, branch := range p.order {
+		for _, taken := range branch.taken {
+			if taken > 0 {
+				result.covered++
+			}
+		}
+	}
+
+	return result
+}
+
+func (p *Profile) sortedLines() []int32 {
+	lines := make([]int32, 0, len(p.statements))
+	for line := range p.statements {
+		lines = append(lines, line)
+	}
+	slices.Sort(lines)
+
+	return lines
+}
+
+// WriteSummary writes a table with the statement and branch coverage of each profile.
+func WriteSummary(w io.Writer, profiles []*Profile) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "File\tStatements\tBranches")
+
+	var statements, branches stats
+	for _, profile := range profiles {
+		fileStatements, fileBranches := profile.statementStats(), profile.branchStats()
+		fmt.Fprintf(table, "%s\t%s\t%s\n", profile.Path, fileStatements, fileBranches)
+
+		statements = statements.add(fileStatements)
+		branches = branches.add(fileBranches)
+	}
+	fmt.Fprintf(table, "Total\t%s\t%s\n", statements, branches)
+
+	return table.Flush()
+}
+
+// WriteLCOV writes the profiles in the LCOV tracefile format. Each branch is a block with two
+// branches, in the order of Tracer.Branch: the first arm, then the second one.
+func WriteLCOV(w io.Writer, profiles []*Profile) error {
+	var out strings.Builder
+
+	for _, profile := range profiles {
+		fmt.Fprintf(&out, "TN:\nSF:%s\n", profile.Path)
+
+		blocks := make(map[int32]int)
+		for _, branch := range profile.order {
+			for arm, taken := range branch.taken {
+				count := "-"
+				if branch.evaluated() {
+					count = fmt.Sprint(taken)
+				}
+				fmt.Fprintf(&out, "BRDA:%d,%d,%d,%s\n", branch.line, blocks[branch.line], arm, count)
+			}
+			blocks[branch.line]++
+		}
+		branches := profile.branchStats()
+		fmt.Fprintf(&out, "BRF:%d\nBRH:%d\n", branches.total, branches.covered)
+
+		for _, line := range profile.sortedLines() {
+			fmt.Fprintf(&out, "DA:%d,%d\n", line, profile.statements[line])
+		}
+		statements := profile.statementStats()
+		fmt.Fprintf(&out, "LF:%d\nLH:%d\nend_of_record\n", statements.total, statements.covered)
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+type htmlFile struct {
+	Path       string
+	Statements string
+	Branches   string
+	Lines      []htmlLine
+}
+
+type htmlLine struct {
+	Number int
+	Source string
+	// Class is "covered", "uncovered" or "partial" for lines with statements or branches.
+	Class string
+	Count string
+	Title string
+}
+
+// WriteHTML writes a page with the source of each profile, where lines that ran are green, lines
+// that didn't are red, and lines with a branch that only took one of its arms are yellow.
+func WriteHTML(w io.Writer, profiles []*Profile) error {
+	files := make([]htmlFile, 0, len(profiles))
+	for _, profile := range profiles {
+		files = append(files, profile.htmlFile())
+	}
+
+	return htmlReport.Execute(w, files)
+}
+
+func (p *Profile) htmlFile() htmlFile {
+	file := htmlFile{Path: p.Path, Statements: p.statementStats().String(), Branches: p.branchStats().String()}
+
+	for idx, source := range p.lines {
+		line := htmlLine{Number: idx + 1, Source: source}
+		count, isStatement := p.statements[int32(idx+1)]
+
+		if isStatement {
+			line.Count = fmt.Sprint(count)
+			line.Class = "uncovered"
+			if count > 0 {
+				line.Class = "covered"
+			}
+		}
+
+		branches := make([]string, 0)
+		for _, branch := range p.order {
+			if branch.line != int32(idx+1) {
+				continue
+			}
+
+			branches = append(branches, fmt.Sprintf("%s: %d/%d", branch.kind, branch.taken[0], branch.taken[1]))
+			if line.Class != "uncovered" && (branch.taken[0] == 0 || branch.taken[1] == 0) {
+				line.Class = "partial"
+			}
+		}
+		if len(branches) != 0 {
+			line.Title = "Branches taken (first/second arm): " + strings.Join(branches, ", ")
+		}
+
+		file.Lines = append(file.Lines, line)
+	}
+
+	return file
+}
+
+var htmlReport = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Glox coverage</title>
+<style>
+body { font-family: sans-serif; }
+table.source { border-collapse: collapse; font-family: monospace; white-space: pre; }
+table.source td { padding: 0 8px; }
+td.number, td.count { color: #888; text-align: right; }
+tr.covered td.code { background: #dfd; }
+tr.uncovered td.code { background: #fdd; }
+tr.partial td.code { background: #ffc; }
+</style>
+</head>
+<body>
+{{range .}}
+<h2>{{.Path}}</h2>
+<p>Statements: {{.Statements}}, branches: {{.Branches}}</p>
+<table class="source">
+{{range .Lines}}<tr class="{{.Class}}"{{if .Title}} title="{{.Title}}"{{end}}><td class="number">{{.Number}}</td><td class="count">{{.Count}}</td><td class="code">{{.Source}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
@@ -7,6 +7,7 @@ import (
 type loxArray struct {
 	elements []any
 	token    scanner.Token
+	frozen   bool
 }
 
 func newLoxArray(elements []any) *loxArray {
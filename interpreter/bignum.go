@@ -0,0 +1,339 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/scanner"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	roundingUp       = "up"
+	roundingDown     = "down"
+	roundingCeiling  = "ceiling"
+	roundingFloor    = "floor"
+	roundingHalfUp   = "half_up"
+	roundingHalfDown = "half_down"
+	roundingHalfEven = "half_even"
+)
+
+var roundingModes = map[string]bool{
+	roundingUp:       true,
+	roundingDown:     true,
+	roundingCeiling:  true,
+	roundingFloor:    true,
+	roundingHalfUp:   true,
+	roundingHalfDown: true,
+	roundingHalfEven: true,
+}
+
+// decimalContext controls how inexact decimal results (division and round()) are rounded.
+// Precision is the number of fractional digits kept by a division.
+type decimalContext struct {
+	precision int32
+	rounding  string
+}
+
+func newDecimalContext() decimalContext {
+	return decimalContext{precision: 16, rounding: roundingHalfEven}
+}
+
+type loxBigInt struct {
+	value *big.Int
+}
+
+func newLoxBigInt(value *big.Int) *loxBigInt {
+	return &loxBigInt{value: value}
+}
+
+func parseLoxBigInt(str string) (*loxBigInt, bool) {
+	value, ok := new(big.Int).SetString(strings.TrimSpace(str), 10)
+	if !ok {
+		return nil, false
+	}
+
+	return newLoxBigInt(value), true
+}
+
+func (b *loxBigInt) String() string {
+	return b.value.String()
+}
+
+// loxDecimal is an exact decimal number equal to unscaled * 10^-scale.
+type loxDecimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func newLoxDecimal(unscaled *big.Int, scale int32) *loxDecimal {
+	return &loxDecimal{unscaled: unscaled, scale: scale}
+}
+
+func parseLoxDecimal(str string) (*loxDecimal, bool) {
+	str = strings.TrimSpace(str)
+	integer, fraction, _ := strings.Cut(str, ".")
+
+	if strings.ContainsAny(fraction, "+-") || (len(fraction) == 0 && strings.HasSuffix(str, ".")) {
+		return nil, false
+	}
+
+	unscaled, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return nil, false
+	}
+
+	return newLoxDecimal(unscaled, int32(len(fraction))), true
+}
+
+func decimalFromFloat(value float64) (*loxDecimal, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, false
+	}
+
+	return parseLoxDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func decimalFromBigInt(value *loxBigInt) *loxDecimal {
+	return newLoxDecimal(value.value, 0)
+}
+
+func (d *loxDecimal) rescale(scale int32, rounding string) *loxDecimal {
+	if scale >= d.scale {
+		return newLoxDecimal(new(big.Int).Mul(d.unscaled, pow10(scale-d.scale)), scale)
+	}
+
+	return newLoxDecimal(roundQuotient(d.unscaled, pow10(d.scale-scale), rounding), scale)
+}
+
+// trim drops trailing fractional zeros while keeping at least minScale fractional digits.
+func (d *loxDecimal) trim(minScale int32) *loxDecimal {
+	unscaled, scale := d.unscaled, d.scale
+	ten, remainder := big.NewInt(10), new(big.Int)
+
+	for scale > minScale {
+		quotient, _ := new(big.Int).QuoRem(unscaled, ten, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		unscaled, scale = quotient, scale-1
+	}
+
+	return newLoxDecimal(unscaled, scale)
+}
+
+func (d *loxDecimal) float() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+func (d *loxDecimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled).String()
+	sign := ""
+	if d.unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// roundQuotient divides numerator by denominator and rounds the result according to the rounding mode.
+func roundQuotient(numerator *big.Int, denominator *big.Int, rounding string) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	sign := int64(numerator.Sign() * denominator.Sign())
+	half := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).CmpAbs(denominator)
+	awayFromZero := false
+
+	switch rounding {
+	case roundingUp:
+		awayFromZero = true
+	case roundingDown:
+		awayFromZero = false
+	case roundingCeiling:
+		awayFromZero = sign > 0
+	case roundingFloor:
+		awayFromZero = sign < 0
+	case roundingHalfUp:
+		awayFromZero = half >= 0
+	case roundingHalfDown:
+		awayFromZero = half > 0
+	case roundingHalfEven:
+		awayFromZero = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	}
+
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(sign))
+	}
+
+	return quotient
+}
+
+func isBigNumber(obj any) bool {
+	switch obj.(type) {
+	case *loxBigInt, *loxDecimal:
+		return true
+	}
+	return false
+}
+
+func isNumeric(obj any) bool {
+	_, ok := obj.(float64)
+	return ok || isBigNumber(obj)
+}
+
+// promoteBigNumbers converts a pair of numeric operands into a common exact type. Big integers
+// stay big integers only when the other operand is integral, otherwise both become decimals.
+func promoteBigNumbers(obj1 any, obj2 any, token scanner.Token) (any, any, error) {
+	_, decimal1 := obj1.(*loxDecimal)
+	_, decimal2 := obj2.(*loxDecimal)
+	asDecimals := decimal1 || decimal2
+
+	for _, obj := range []any{obj1, obj2} {
+		if number, ok := obj.(float64); ok && number != math.Trunc(number) {
+			asDecimals = true
+		}
+	}
+
+	convert := func(obj any) (any, error) {
+		switch value := obj.(type) {
+		case *loxBigInt:
+			if asDecimals {
+				return decimalFromBigInt(value), nil
+			}
+			return value, nil
+		case *loxDecimal:
+			return value, nil
+		case float64:
+			if !asDecimals && !math.IsInf(value, 0) {
+				bigValue, _ := big.NewFloat(value).Int(nil)
+				return newLoxBigInt(bigValue), nil
+			}
+			if decimal, ok := decimalFromFloat(value); ok {
+				return decimal, nil
+			}
+		}
+		return nil, &Error{Token: token, Message: fmt.Sprintf("Can't convert '%v' to an exact number.", obj)}
+	}
+
+	left, err := convert(obj1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	right, err := convert(obj2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return left, right, nil
+}
+
+func compareBigNumbers(obj1 any, obj2 any, token scanner.Token) (int, error) {
+	left, right, err := promoteBigNumbers(obj1, obj2, token)
+	if err != nil {
+		return 0, err
+	}
+
+	if leftInt, ok := left.(*loxBigInt); ok {
+		return leftInt.value.Cmp(right.(*loxBigInt).value), nil
+	}
+
+	leftDecimal, rightDecimal := alignDecimals(left.(*loxDecimal), right.(*loxDecimal))
+	return leftDecimal.unscaled.Cmp(rightDecimal.unscaled), nil
+}
+
+func alignDecimals(left *loxDecimal, right *loxDecimal) (*loxDecimal, *loxDecimal) {
+	scale := max(left.scale, right.scale)
+	return left.rescale(scale, roundingDown), right.rescale(scale, roundingDown)
+}
+
+func bigIntArithmetic(operator scanner.Token, left *big.Int, right *big.Int) (any, error) {
+	result := new(big.Int)
+
+	switch operator.Type {
+	case scanner.PLUS:
+		result.Add(left, right)
+	case scanner.MINUS:
+		result.Sub(left, right)
+	case scanner.STAR:
+		result.Mul(left, right)
+	case scanner.SLASH, scanner.MODULO:
+		if right.Sign() == 0 {
+			return nil, &Error{Token: operator, Message: "Division by zero is prohibited."}
+		}
+		if operator.Type == scanner.SLASH {
+			result.Quo(left, right)
+		} else {
+			result.Rem(left, right)
+		}
+	}
+
+	return newLoxBigInt(result), nil
+}
+
+func decimalArithmetic(operator scanner.Token, left *loxDecimal, right *loxDecimal, context decimalContext) (any, error) {
+	switch operator.Type {
+	case scanner.STAR:
+		return newLoxDecimal(new(big.Int).Mul(left.unscaled, right.unscaled), left.scale+right.scale), nil
+	case scanner.SLASH:
+		if right.unscaled.Sign() == 0 {
+			return nil, &Error{Token: operator, Message: "Division by zero is prohibited."}
+		}
+
+		minScale := max(left.scale, right.scale)
+		scale := max(context.precision, minScale)
+		numerator := new(big.Int).Mul(left.unscaled, pow10(scale+right.scale))
+		denominator := new(big.Int).Mul(right.unscaled, pow10(left.scale))
+
+		return newLoxDecimal(roundQuotient(numerator, denominator, context.rounding), scale).trim(minScale), nil
+	}
+
+	alignedLeft, alignedRight := alignDecimals(left, right)
+	result, err := bigIntArithmetic(operator, alignedLeft.unscaled, alignedRight.unscaled)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLoxDecimal(result.(*loxBigInt).value, alignedLeft.scale), nil
+}
+
+func bigNumberArithmetic(operator scanner.Token, obj1 any, obj2 any, context decimalContext) (any, error) {
+	left, right, err := promoteBigNumbers(obj1, obj2, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	if leftInt, ok := left.(*loxBigInt); ok {
+		return bigIntArithmetic(operator, leftInt.value, right.(*loxBigInt).value)
+	}
+
+	return decimalArithmetic(operator, left.(*loxDecimal), right.(*loxDecimal), context)
+}
+
+func negateBigNumber(obj any) any {
+	switch value := obj.(type) {
+	case *loxBigInt:
+		return newLoxBigInt(new(big.Int).Neg(value.value))
+	case *loxDecimal:
+		return newLoxDecimal(new(big.Int).Neg(value.unscaled), value.scale)
+	}
+	return nil
+}
@@ -7,14 +7,36 @@ import (
 	"glox/scanner"
 )
 
+// variadic is the maximum arity of callables that accept any number of arguments.
+const variadic = -1
+
 type callable interface {
-	arity() int32
+	// arity returns the minimum and maximum number of arguments the callable accepts.
+	arity() (int32, int32)
 	call(*Interpreter, []any, scanner.Token) (any, error)
 }
 
+// parameterized is implemented by callables whose parameters can be passed by name.
+type parameterized interface {
+	parameters() []parser.Parameter
+}
+
+// missingArgument fills the positions of parameters skipped by a call with named arguments, so
+// the parameter gets its default value.
+type missingArgument struct{}
+
+// ownerVariable holds the class that declared a bound method. '#' can't start a variable name, so
+// it never clashes with user variables.
+const ownerVariable = "#class"
+
+// traitVariable holds the trait that declared a bound trait method.
+const traitVariable = "#trait"
+
 type loxFunction struct {
 	funStmt            parser.FunctionStmt
 	closure            *environment
+	owner              *loxClass
+	trait              *loxTrait
 	isClassInitializer bool
 	isClassGetter      bool
 }
@@ -46,21 +68,78 @@ func newLoxStaticMethod(funStmt parser.FunctionStmt, closure *environment) *loxF
 func (f *loxFunction) bind(i loxAbstractInstance) *loxFunction {
 	env := newEnvironment(f.closure)
 	env.define("this", i)
-	return newLoxMethod(f.funStmt, env)
+
+	if f.owner != nil {
+		env.define(ownerVariable, f.owner)
+	}
+
+	if f.trait != nil {
+		env.define(traitVariable, f.trait)
+	}
+
+	method := newLoxMethod(f.funStmt, env)
+	method.owner = f.owner
+	method.trait = f.trait
+
+	return method
+}
+
+// name is how the function is shown when tracing: methods are prefixed with their class or trait.
+func (f *loxFunction) name() string {
+	switch {
+	case f.owner != nil:
+		return f.owner.metaClass.stmt.Name.Lexeme + "." + f.funStmt.Name.Lexeme
+	case f.trait != nil:
+		return f.trait.Name().Lexeme + "." + f.funStmt.Name.Lexeme
+	}
+
+	return f.funStmt.Name.Lexeme
+}
+
+func (f *loxFunction) arity() (int32, int32) {
+	required := int32(0)
+
+	for _, parameter := range f.funStmt.Parameters {
+		if parameter.Rest {
+			return required, variadic
+		}
+		if parameter.Default == nil {
+			required++
+		}
+	}
+
+	return required, int32(len(f.funStmt.Parameters))
 }
 
-func (f *loxFunction) arity() int32 {
-	return int32(len(f.funStmt.Parameters))
+func (f *loxFunction) parameters() []parser.Parameter {
+	return f.funStmt.Parameters
 }
 
 func (f *loxFunction) call(interpreter *Interpreter, arguments []any, _ scanner.Token) (any, error) {
+	if interpreter.tracer != nil {
+		interpreter.tracer.Call(f.name(), f.funStmt.Name.Line)
+		defer interpreter.tracer.Return()
+	}
+
 	newEnv := newEnvironment(f.closure)
 
-	for i := 0; i < len(arguments); i++ {
-		newEnv.define(f.funStmt.Parameters[i].Lexeme, arguments[i])
+	for i, parameter := range f.funStmt.Parameters {
+		value, err := f.argument(interpreter, parameter, i, arguments, newEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		if parameter.Pattern == nil {
+			newEnv.define(parameter.Name.Lexeme, value)
+		} else if err := interpreter.destructure(parameter.Pattern, value, newEnv); err != nil {
+			return nil, err
+		}
 	}
 
-	if _, err := interpreter.executeBlock(f.funStmt.Body, newEnv); err != nil {
+	interpreter.deferred = append(interpreter.deferred, nil)
+	_, err := interpreter.executeBlock(f.funStmt.Body, newEnv)
+
+	if err = interpreter.runDeferred(err); err != nil {
 		returnInterrupt := &parser.ReturnInterrupt{}
 		if errors.As(err, &returnInterrupt) {
 			// Handle "return;" edge case
@@ -83,6 +162,36 @@ func (f *loxFunction) call(interpreter *Interpreter, arguments []any, _ scanner.
 	return nil, nil
 }
 
+// argument returns the value of the parameter at position idx. Defaults are evaluated in the
+// function's environment, so they can refer to the parameters before them.
+func (f *loxFunction) argument(interpreter *Interpreter, parameter parser.Parameter, idx int, arguments []any, env *environment) (any, error) {
+	if parameter.Rest {
+		rest := make([]any, 0)
+		if idx < len(arguments) {
+			rest = append(rest, arguments[idx:]...)
+		}
+		return newLoxArray(rest), nil
+	}
+
+	if idx < len(arguments) {
+		if _, missing := arguments[idx].(missingArgument); !missing {
+			return arguments[idx], nil
+		}
+	}
+
+	if parameter.Default == nil {
+		return nil, nil
+	}
+
+	previous := interpreter.environment
+	interpreter.environment = env
+	defer func() {
+		interpreter.environment = previous
+	}()
+
+	return interpreter.Evaluate(parameter.Default)
+}
+
 func (f *loxFunction) String() string {
 	return fmt.Sprintf("<fn %s>", f.funStmt.Name.Lexeme)
 }
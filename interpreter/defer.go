@@ -0,0 +1,95 @@
+package interpreter
+
+import (
+	"glox/parser"
+	"glox/scanner"
+)
+
+// deferredExpr is an expression deferred until its function returns, along with the environment
+// of the defer statement it's evaluated in.
+type deferredExpr struct {
+	expr parser.Expr
+	env  *environment
+}
+
+func (i *Interpreter) VisitDeferStmt(stmt parser.DeferStmt) (any, error) {
+	frame := len(i.deferred) - 1
+	i.deferred[frame] = append(i.deferred[frame], deferredExpr{expr: stmt.Expr, env: i.environment})
+
+	return nil, nil
+}
+
+// runDeferred evaluates the expressions deferred by the function call that is returning, the last
+// one first. err is how the body of the function ended: an error raised by a deferred expression
+// only replaces it when the body didn't fail itself.
+func (i *Interpreter) runDeferred(err error) error {
+	frame := i.deferred[len(i.deferred)-1]
+	i.deferred = i.deferred[:len(i.deferred)-1]
+
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	for idx := len(frame) - 1; idx >= 0; idx-- {
+		i.environment = frame[idx].env
+		if _, deferredErr := i.Evaluate(frame[idx].expr); deferredErr != nil && (err == nil || isInterrupt(err)) {
+			err = deferredErr
+		}
+	}
+
+	return err
+}
+
+// VisitUsingStmt runs the body with the resource bound to its name, and calls the close() method
+// of the resource however the body is left. A nil resource is not closed.
+func (i *Interpreter) VisitUsingStmt(stmt parser.UsingStmt) (any, error) {
+	resource, err := i.Evaluate(stmt.Initializer)
+	if err != nil {
+		return nil, err
+	}
+
+	var closer callable
+	if resource != nil {
+		if closer, err = i.closeMethod(resource, stmt.Keyword); err != nil {
+			return nil, err
+		}
+	}
+
+	env := newEnvironment(i.environment)
+	env.define(stmt.Name.Lexeme, resource)
+	_, err = i.executeBlock([]parser.Stmt{stmt.Body}, env)
+
+	if closer != nil {
+		if _, closeErr := closer.call(i, make([]any, 0), stmt.Keyword); closeErr != nil && (err == nil || isInterrupt(err)) {
+			err = closeErr
+		}
+	}
+
+	return nil, err
+}
+
+func (i *Interpreter) closeMethod(resource any, keyword scanner.Token) (callable, error) {
+	notClosable := i.newError(keyword, "Resources of 'using' should have a close() method without parameters.")
+
+	instance, ok := resource.(loxAbstractInstance)
+	if !ok {
+		return nil, notClosable
+	}
+
+	method, err := instance.get(scanner.Token{Type: scanner.IDENTIFIER, Lexeme: "close", Line: keyword.Line})
+	if err != nil {
+		return nil, notClosable
+	}
+
+	closer, ok := method.(callable)
+	if !ok {
+		return nil, notClosable
+	}
+
+	if minArity, _ := closer.arity(); minArity != 0 {
+		return nil, notClosable
+	}
+
+	return closer, nil
+}
@@ -0,0 +1,193 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/parser"
+	"glox/scanner"
+	"strings"
+)
+
+type loxEnum struct {
+	stmt parser.EnumStmt
+	// variants are kept in declaration order, which is the order of 'values' and of ordinals.
+	variants      []*loxEnumVariant
+	methods       map[string]*loxFunction
+	staticMethods map[string]*loxFunction
+}
+
+func newLoxEnum(stmt parser.EnumStmt, methods map[string]*loxFunction, staticMethods map[string]*loxFunction) *loxEnum {
+	return &loxEnum{stmt: stmt, variants: make([]*loxEnumVariant, 0, len(stmt.Variants)), methods: methods, staticMethods: staticMethods}
+}
+
+func (e *loxEnum) Name() scanner.Token {
+	return e.stmt.Name
+}
+
+// get returns a variant, 'values' or a static method. Variants without associated values are
+// returned as their single value, the others as the callable that constructs a value.
+func (e *loxEnum) get(name scanner.Token) (any, error) {
+	for _, variant := range e.variants {
+		if variant.name() == name.Lexeme {
+			return variant.member(), nil
+		}
+	}
+
+	if method, ok := e.staticMethods[name.Lexeme]; ok {
+		return method, nil
+	}
+
+	if name.Lexeme == "values" {
+		return newLoxArray(e.values()), nil
+	}
+
+	return nil, &Error{Token: name, Message: fmt.Sprintf("Undefined variant '%s' of enum '%s'.", name.Lexeme, e.Name().Lexeme)}
+}
+
+// values returns the members of the variants in declaration order, which is also the order in
+// which enums are iterated.
+func (e *loxEnum) values() []any {
+	values := make([]any, 0, len(e.variants))
+	for _, variant := range e.variants {
+		values = append(values, variant.member())
+	}
+
+	return values
+}
+
+// set is never reached, as enums can't be modified.
+func (e *loxEnum) set(_ scanner.Token, _ any) {
+}
+
+func (e *loxEnum) String() string {
+	return fmt.Sprintf("<enum %s>", e.Name().Lexeme)
+}
+
+type loxEnumVariant struct {
+	enum    *loxEnum
+	stmt    parser.EnumVariant
+	ordinal int
+	// constructor binds the arguments of a call to the variant's parameters. It's nil for
+	// singleton variants.
+	constructor *loxFunction
+	singleton   *loxEnumValue
+}
+
+func newLoxEnumVariant(enum *loxEnum, stmt parser.EnumVariant, ordinal int, closure *environment) *loxEnumVariant {
+	variant := &loxEnumVariant{enum: enum, stmt: stmt, ordinal: ordinal}
+
+	if stmt.Parameters == nil {
+		variant.singleton = &loxEnumValue{variant: variant, values: make([]any, 0)}
+	} else {
+		variant.constructor = newLoxFunction(parser.FunctionStmt{Name: stmt.Name, Parameters: stmt.Parameters, Body: make([]parser.Stmt, 0)}, closure)
+	}
+
+	return variant
+}
+
+func (v *loxEnumVariant) name() string {
+	return v.stmt.Name.Lexeme
+}
+
+func (v *loxEnumVariant) member() any {
+	if v.singleton != nil {
+		return v.singleton
+	}
+
+	return v
+}
+
+func (v *loxEnumVariant) arity() (int32, int32) {
+	return v.constructor.arity()
+}
+
+func (v *loxEnumVariant) parameters() []parser.Parameter {
+	return v.stmt.Parameters
+}
+
+func (v *loxEnumVariant) call(interpreter *Interpreter, arguments []any, _ scanner.Token) (any, error) {
+	env := newEnvironment(v.constructor.closure)
+	values := make([]any, 0, len(v.stmt.Parameters))
+
+	for idx, parameter := range v.stmt.Parameters {
+		value, err := v.constructor.argument(interpreter, parameter, idx, arguments, env)
+		if err != nil {
+			return nil, err
+		}
+
+		env.define(parameter.Name.Lexeme, value)
+		values = append(values, value)
+	}
+
+	return &loxEnumValue{variant: v, values: values}, nil
+}
+
+func (v *loxEnumVariant) String() string {
+	return fmt.Sprintf("<variant %s.%s>", v.enum.Name().Lexeme, v.name())
+}
+
+// loxEnumValue is a variant of an enum along with the values it carries, one per parameter of
+// the variant.
+type loxEnumValue struct {
+	variant *loxEnumVariant
+	values  []any
+}
+
+// get returns an associated value, a method bound to the value, or the 'name' and 'ordinal' of
+// its variant.
+func (v *loxEnumValue) get(name scanner.Token) (any, error) {
+	for idx, parameter := range v.variant.stmt.Parameters {
+		if parameter.Name.Lexeme == name.Lexeme {
+			return v.values[idx], nil
+		}
+	}
+
+	if method, ok := v.variant.enum.methods[name.Lexeme]; ok {
+		return method.bind(v), nil
+	}
+
+	switch name.Lexeme {
+	case "name":
+		return v.variant.name(), nil
+	case "ordinal":
+		return float64(v.variant.ordinal), nil
+	}
+
+	return nil, &Error{Token: name, Message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme)}
+}
+
+// set is never reached, as enum values can't be modified.
+func (v *loxEnumValue) set(_ scanner.Token, _ any) {
+}
+
+// equals reports whether both values are the same variant carrying equal values.
+func (v *loxEnumValue) equals(interpreter *Interpreter, other *loxEnumValue) bool {
+	if v.variant != other.variant {
+		return false
+	}
+
+	for idx, value := range v.values {
+		if !interpreter.areEqual(value, other.values[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (i *Interpreter) stringifyEnumValue(value *loxEnumValue) (string, error) {
+	name := fmt.Sprintf("%s.%s", value.variant.enum.Name().Lexeme, value.variant.name())
+	if value.variant.singleton != nil {
+		return name, nil
+	}
+
+	values := make([]string, 0, len(value.values))
+	for _, element := range value.values {
+		text, err := i.Stringify(element)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, text)
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(values, ", ")), nil
+}
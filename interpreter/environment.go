@@ -1,8 +1,19 @@
 package interpreter
 
+import "errors"
+
+var (
+	errUndefinedVariable     = errors.New("undefined variable")
+	errConstantAssignment    = errors.New("assignment to a constant")
+	errConstantRedeclaration = errors.New("redeclaration of a constant")
+)
+
 type environment struct {
 	enclosing *environment
 	values    map[string]any
+	// constants holds the names bound with 'const' in this environment. It stays nil until the
+	// first constant is defined, as most environments never see one.
+	constants map[string]bool
 }
 
 func newEnvironment(enclosing *environment) *environment {
@@ -36,23 +47,47 @@ func (e *environment) getAt(name string, depth int32) (any, bool) {
 	return e.ancestor(depth).get(name)
 }
 
-func (e *environment) define(name string, value any) {
+// define binds name in this environment. Redeclaring a variable replaces it, but a constant
+// can't be redeclared.
+func (e *environment) define(name string, value any) error {
+	if e.constants[name] {
+		return errConstantRedeclaration
+	}
+
+	e.values[name] = value
+	return nil
+}
+
+func (e *environment) defineConstant(name string, value any) error {
+	if e.constants[name] {
+		return errConstantRedeclaration
+	}
+
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
+
 	e.values[name] = value
+	e.constants[name] = true
+	return nil
 }
 
-func (e *environment) assign(name string, value any) bool {
+func (e *environment) assign(name string, value any) error {
 	if _, ok := e.values[name]; ok {
+		if e.constants[name] {
+			return errConstantAssignment
+		}
 		e.values[name] = value
-		return true
+		return nil
 	}
 
 	if e.enclosing != nil {
 		return e.enclosing.assign(name, value)
 	}
 
-	return false
+	return errUndefinedVariable
 }
 
-func (e *environment) assignAt(name string, value any, depth int32) bool {
+func (e *environment) assignAt(name string, value any, depth int32) error {
 	return e.ancestor(depth).assign(name, value)
 }
@@ -1,7 +1,9 @@
 package interpreter
 
 import (
+	"errors"
 	"fmt"
+	"glox/parser"
 	"glox/scanner"
 )
 
@@ -13,3 +15,31 @@ type Error struct {
 func (e *Error) Error() string {
 	return fmt.Sprintf("[line %d] %s\n", e.Token.Line, e.Message)
 }
+
+// AssertionError is raised by an assert statement whose condition is false.
+type AssertionError struct {
+	Token   scanner.Token
+	Message string
+}
+
+func (e *AssertionError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("[line %d] Assertion failed.\n", e.Token.Line)
+	}
+
+	return fmt.Sprintf("[line %d] Assertion failed: %s\n", e.Token.Line, e.Message)
+}
+
+// errShortCircuit is returned by an optional link of a chain that found nil. The enclosing
+// OptionalChainExpr turns it into nil, skipping the rest of the chain.
+var errShortCircuit = errors.New("optional chain short-circuited")
+
+// isInterrupt reports whether err is a return, break or continue unwinding the stack rather than
+// an actual error.
+func isInterrupt(err error) bool {
+	var returnInterrupt *parser.ReturnInterrupt
+	var breakInterrupt *parser.BreakInterrupt
+	var continueInterrupt *parser.ContinueInterrupt
+
+	return errors.As(err, &returnInterrupt) || errors.As(err, &breakInterrupt) || errors.As(err, &continueInterrupt)
+}
@@ -0,0 +1,93 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/scanner"
+	"slices"
+	"strings"
+)
+
+// stringifyInstance converts an instance using its toString method. It falls back to the default
+// "<Foo instance>" form when there is no such method, when the method needs arguments or when it
+// ends up converting the same instance again. A toString that fails or returns something other
+// than a string is a runtime error.
+func (i *Interpreter) stringifyInstance(instance *loxInstance) (string, error) {
+	method, ok := instance.class.findMethod("toString")
+	if !ok || method.isClassGetter || i.stringifying[instance] {
+		return instance.String(), nil
+	}
+
+	if minArity, _ := method.arity(); minArity != 0 {
+		return instance.String(), nil
+	}
+
+	i.stringifying[instance] = true
+	defer delete(i.stringifying, instance)
+
+	name := scanner.Token{Type: scanner.IDENTIFIER, Lexeme: "toString", Line: method.funStmt.Name.Line}
+	value, err := method.bind(instance).call(i, make([]any, 0), name)
+	if err != nil {
+		return "", err
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return "", i.newError(name, "toString() should return a string.")
+	}
+
+	return text, nil
+}
+
+// inspect describes value for debugging, listing the class and fields of instances recursively.
+// Instances that are already being inspected are printed as "<cycle Foo>".
+func (i *Interpreter) inspect(value any, visiting map[any]bool) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", value), nil
+	case *loxArray:
+		if visiting[value] {
+			return "<cycle array>", nil
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+
+		elements := make([]string, 0, len(value.elements))
+		for _, element := range value.elements {
+			text, err := i.inspect(element, visiting)
+			if err != nil {
+				return "", err
+			}
+			elements = append(elements, text)
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case *loxInstance:
+		name := value.class.metaClass.stmt.Name.Lexeme
+		if visiting[value] {
+			return fmt.Sprintf("<cycle %s>", name), nil
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+
+		if len(value.fields) == 0 {
+			return name + " {}", nil
+		}
+
+		names := make([]string, 0, len(value.fields))
+		for field := range value.fields {
+			names = append(names, field)
+		}
+		slices.Sort(names)
+
+		fields := make([]string, 0, len(names))
+		for _, field := range names {
+			text, err := i.inspect(value.fields[field], visiting)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, field+": "+text)
+		}
+		return name + " { " + strings.Join(fields, ", ") + " }", nil
+	}
+
+	return i.Stringify(value)
+}
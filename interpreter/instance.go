@@ -15,13 +15,26 @@ type loxMetaClass struct {
 	stmt          parser.ClassStmt
 	methods       map[string]*loxFunction
 	staticMethods map[string]*loxFunction
+	setters       map[string]*loxFunction
+	staticSetters map[string]*loxFunction
+	// fields are the declared instance fields, including the ones provided by traits. Their
+	// initializers are evaluated in closure for every new instance.
+	fields  []parser.VarStmt
+	closure *environment
+	// traits are the traits listed in the class declaration.
+	traits []*loxTrait
 }
 
-func newMetaClass(class parser.ClassStmt, methods map[string]*loxFunction, staticMethods map[string]*loxFunction) *loxMetaClass {
+func newMetaClass(class parser.ClassStmt, methods map[string]*loxFunction, staticMethods map[string]*loxFunction, setters map[string]*loxFunction, staticSetters map[string]*loxFunction, fields []parser.VarStmt, closure *environment, traits []*loxTrait) *loxMetaClass {
 	return &loxMetaClass{
 		stmt:          class,
+		traits:        traits,
 		methods:       methods,
 		staticMethods: staticMethods,
+		setters:       setters,
+		staticSetters: staticSetters,
+		fields:        fields,
+		closure:       closure,
 	}
 }
 
@@ -51,6 +64,62 @@ func (c *loxClass) findMethod(name string) (*loxFunction, bool) {
 	return nil, false
 }
 
+func (c *loxClass) findSetter(name string) (*loxFunction, bool) {
+	for current := c; current != nil; current = current.superclass {
+		if setter, ok := current.metaClass.setters[name]; ok {
+			return setter, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *loxClass) findStaticSetter(name string) (*loxFunction, bool) {
+	for current := c; current != nil; current = current.superclass {
+		if setter, ok := current.metaClass.staticSetters[name]; ok {
+			return setter, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *loxClass) inherits(class *loxClass) bool {
+	for current := c; current != nil; current = current.superclass {
+		if current == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unimplementedMethod returns the first abstract method of a superclass that the class neither
+// implements nor inherits an implementation of, along with the class that declared it.
+func (c *loxClass) unimplementedMethod() (scanner.Token, *loxClass, bool) {
+	for current := c.superclass; current != nil; current = current.superclass {
+		for _, method := range current.metaClass.stmt.AbstractMethods {
+			if _, ok := c.findMethod(method.Name.Lexeme); !ok {
+				return method.Name, current, true
+			}
+		}
+	}
+
+	return scanner.Token{}, nil, false
+}
+
+func (c *loxClass) implements(trait *loxTrait) bool {
+	for current := c; current != nil; current = current.superclass {
+		for _, used := range current.metaClass.traits {
+			if used.implements(trait) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (c *loxClass) get(name scanner.Token) (any, error) {
 	if field, ok := c.staticFields[name.Lexeme]; ok {
 		return field, nil
@@ -71,17 +140,33 @@ func (c *loxClass) set(name scanner.Token, value any) {
 	c.staticFields[name.Lexeme] = value
 }
 
-func (c *loxClass) arity() int32 {
+func (c *loxClass) arity() (int32, int32) {
 	if initializer, ok := c.metaClass.methods["init"]; ok {
 		return initializer.arity()
 	}
 
-	return 0
+	return 0, 0
+}
+
+func (c *loxClass) parameters() []parser.Parameter {
+	if initializer, ok := c.metaClass.methods["init"]; ok {
+		return initializer.parameters()
+	}
+
+	return nil
 }
 
 func (c *loxClass) call(interpreter *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	if c.metaClass.stmt.Abstract {
+		return nil, &Error{Token: token, Message: fmt.Sprintf("Can't instantiate abstract class '%s'.", c.metaClass.stmt.Name.Lexeme)}
+	}
+
 	instance := &loxInstance{class: c, fields: make(map[string]any)}
 
+	if err := c.initializeFields(interpreter, instance); err != nil {
+		return nil, err
+	}
+
 	if initializer, ok := c.metaClass.methods["init"]; ok {
 		return initializer.bind(instance).call(interpreter, arguments, token)
 	}
@@ -89,6 +174,39 @@ func (c *loxClass) call(interpreter *Interpreter, arguments []any, token scanner
 	return instance, nil
 }
 
+// initializeFields sets the declared fields of instance, starting with the ones of the root class
+// so subclasses can override their defaults.
+func (c *loxClass) initializeFields(interpreter *Interpreter, instance *loxInstance) error {
+	if c.superclass != nil {
+		if err := c.superclass.initializeFields(interpreter, instance); err != nil {
+			return err
+		}
+	}
+
+	if len(c.metaClass.fields) == 0 {
+		return nil
+	}
+
+	env := newEnvironment(c.metaClass.closure)
+	env.define("this", instance)
+	env.define(ownerVariable, c)
+
+	for _, field := range c.metaClass.fields {
+		value, err := interpreter.evaluateField(field, env)
+		if err != nil {
+			return err
+		}
+
+		if field.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			instance.setPrivate(c, field.Name, value)
+		} else {
+			instance.set(field.Name, value)
+		}
+	}
+
+	return nil
+}
+
 func (c *loxClass) String() string {
 	return fmt.Sprintf("<class %s>", c.metaClass.stmt.Name.Lexeme)
 }
@@ -96,6 +214,11 @@ func (c *loxClass) String() string {
 type loxInstance struct {
 	class  *loxClass
 	fields map[string]any
+	// privateFields are kept apart for every class in the hierarchy, so a subclass can't see or
+	// overwrite the private fields of its superclass.
+	privateFields map[*loxClass]map[string]any
+	// frozen instances reject any change to their fields, public or private.
+	frozen bool
 }
 
 func (i *loxInstance) get(name scanner.Token) (any, error) {
@@ -114,6 +237,30 @@ func (i *loxInstance) set(name scanner.Token, value any) {
 	i.fields[name.Lexeme] = value
 }
 
+func (i *loxInstance) getPrivate(owner *loxClass, name scanner.Token) (any, error) {
+	if field, ok := i.privateFields[owner][name.Lexeme]; ok {
+		return field, nil
+	}
+
+	if method, ok := owner.metaClass.methods[name.Lexeme]; ok {
+		return method.bind(i), nil
+	}
+
+	return nil, &Error{Token: name, Message: fmt.Sprintf("Undefined private member '%s' in class '%s'.", name.Lexeme, owner.metaClass.stmt.Name.Lexeme)}
+}
+
+func (i *loxInstance) setPrivate(owner *loxClass, name scanner.Token, value any) {
+	if i.privateFields == nil {
+		i.privateFields = make(map[*loxClass]map[string]any)
+	}
+
+	if i.privateFields[owner] == nil {
+		i.privateFields[owner] = make(map[string]any)
+	}
+
+	i.privateFields[owner][name.Lexeme] = value
+}
+
 func (i *loxInstance) String() string {
 	return fmt.Sprintf("<%s instance>", i.class.metaClass.stmt.Name.Lexeme)
 }
@@ -10,6 +10,7 @@ import (
 	"glox/scanner"
 	"math"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -17,6 +18,11 @@ type Interpreter struct {
 	globalEnvironment *environment
 	environment       *environment
 	locals            map[string]int32
+	decimalContext    decimalContext
+	stringifying      map[*loxInstance]bool
+	// deferred holds the expressions deferred by each function call in progress, innermost last.
+	deferred [][]deferredExpr
+	tracer   Tracer
 }
 
 func New() *Interpreter {
@@ -27,8 +33,26 @@ func New() *Interpreter {
 	globalEnv.define("str", &nativeStringify{})
 	globalEnv.define("append", &nativeAppend{})
 	globalEnv.define("len", &nativeLen{})
-
-	return &Interpreter{globalEnvironment: globalEnv, environment: env, locals: make(map[string]int32)}
+	globalEnv.define("bigint", &nativeBigInt{})
+	globalEnv.define("decimal", &nativeDecimal{})
+	globalEnv.define("decimalContext", &nativeDecimalContext{})
+	globalEnv.define("round", &nativeRound{})
+	globalEnv.define("inspect", &nativeInspect{})
+	globalEnv.define("freeze", &nativeFreeze{})
+	globalEnv.define("type", &nativeType{})
+	globalEnv.define("fields", &nativeFields{})
+	globalEnv.define("bind", &nativeBind{})
+	for _, reflection := range newClassReflections() {
+		globalEnv.define(reflection.name, reflection)
+	}
+
+	return &Interpreter{
+		globalEnvironment: globalEnv,
+		environment:       env,
+		locals:            make(map[string]int32),
+		decimalContext:    newDecimalContext(),
+		stringifying:      make(map[*loxInstance]bool),
+	}
 }
 
 func (i *Interpreter) newError(token scanner.Token, message string) *Error {
@@ -59,6 +83,10 @@ func (i *Interpreter) areStringOperands(obj1 any, obj2 any) bool {
 	return i.isType(obj1, reflect.String) && i.isType(obj2, reflect.String)
 }
 
+func (i *Interpreter) areBigNumberOperands(obj1 any, obj2 any) bool {
+	return (isBigNumber(obj1) || isBigNumber(obj2)) && isNumeric(obj1) && isNumeric(obj2)
+}
+
 func (i *Interpreter) areEqual(obj1 any, obj2 any) bool {
 	if obj1 == nil && obj2 == nil {
 		return true
@@ -66,12 +94,26 @@ func (i *Interpreter) areEqual(obj1 any, obj2 any) bool {
 	if obj1 == nil {
 		return false
 	}
+	if i.areBigNumberOperands(obj1, obj2) {
+		result, err := compareBigNumbers(obj1, obj2, scanner.Token{})
+		return err == nil && result == 0
+	}
+	if value, ok := obj1.(*loxRange); ok {
+		other, ok := obj2.(*loxRange)
+		return ok && value.equals(other)
+	}
+	if value, ok := obj1.(*loxEnumValue); ok {
+		other, ok := obj2.(*loxEnumValue)
+		return ok && value.equals(i, other)
+	}
 	return obj1 == obj2
 }
 
-func (i *Interpreter) Stringify(obj any) string {
+// Stringify converts a value the way print shows it. It fails when the toString method of an
+// instance fails.
+func (i *Interpreter) Stringify(obj any) (string, error) {
 	if obj == nil {
-		return "nil"
+		return "nil", nil
 	}
 
 	array, ok := obj.(*loxArray)
@@ -81,16 +123,28 @@ func (i *Interpreter) Stringify(obj any) string {
 		builder.WriteString("[")
 
 		for idx, element := range array.elements {
-			builder.WriteString(i.Stringify(element))
+			text, err := i.Stringify(element)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(text)
 			if idx+1 != elementsLen {
 				builder.WriteString(", ")
 			}
 		}
 		builder.WriteString("]")
-		return builder.String()
+		return builder.String(), nil
 	}
 
-	return fmt.Sprintf("%v", obj)
+	if instance, ok := obj.(*loxInstance); ok {
+		return i.stringifyInstance(instance)
+	}
+
+	if value, ok := obj.(*loxEnumValue); ok {
+		return i.stringifyEnumValue(value)
+	}
+
+	return fmt.Sprintf("%v", obj), nil
 }
 
 func (i *Interpreter) Evaluate(expr parser.Expr) (any, error) {
@@ -98,6 +152,10 @@ func (i *Interpreter) Evaluate(expr parser.Expr) (any, error) {
 }
 
 func (i *Interpreter) execute(stmt parser.Stmt) (any, error) {
+	if i.tracer != nil {
+		i.tracer.Statement(stmt)
+	}
+
 	return stmt.Accept(i)
 }
 
@@ -144,23 +202,63 @@ func (i *Interpreter) VisitArrayExpr(expr parser.ArrayExpr) (any, error) {
 	elements := make([]any, 0)
 
 	for _, element := range expr.Elements {
+		spread, isSpread := element.(parser.SpreadExpr)
+		if isSpread {
+			element = spread.Expr
+		}
+
 		value, err := i.Evaluate(element)
 		if err != nil {
 			return nil, err
 		}
-		elements = append(elements, value)
+
+		if !isSpread {
+			elements = append(elements, value)
+			continue
+		}
+
+		array, ok, err := i.asArray(value, spread.Ellipsis)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, i.newError(spread.Ellipsis, "Only arrays can be spread.")
+		}
+		elements = append(elements, array.elements...)
 	}
 
 	return newLoxArray(elements), nil
 }
 
+func (i *Interpreter) VisitSpreadExpr(expr parser.SpreadExpr) (any, error) {
+	return nil, i.newError(expr.Ellipsis, "Spread is only allowed inside of arrays.")
+}
+
+func (i *Interpreter) VisitDestructureAssignmentExpr(expr parser.DestructureAssignmentExpr) (any, error) {
+	value, err := i.Evaluate(expr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.assignTargets(expr.Targets, expr.Rest, value, expr.Bracket); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
 func (i *Interpreter) VisitTernaryExpr(ternary parser.TernaryExpr) (any, error) {
 	obj, err := i.Evaluate(ternary.Condition)
 	if err != nil {
 		return nil, err
 	}
 
-	if i.isTruthy(obj) {
+	truthy := i.isTruthy(obj)
+	if i.tracer != nil {
+		i.tracer.Branch(ternary, truthy)
+	}
+
+	if truthy {
 		return i.Evaluate(ternary.Left)
 	}
 
@@ -168,26 +266,36 @@ func (i *Interpreter) VisitTernaryExpr(ternary parser.TernaryExpr) (any, error)
 }
 
 func (i *Interpreter) VisitAssignmentExpr(assignment parser.AssignmentExpr) (any, error) {
-	token := assignment.Name
 	value, err := i.Evaluate(assignment.Value)
 	if err != nil {
 		return nil, err
 	}
 
-	assigned := false
-	depth, ok := i.locals[i.encodeExpression(assignment)]
+	if err := i.assignVariable(assignment, assignment.Name, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (i *Interpreter) assignVariable(expr parser.Expr, token scanner.Token, value any) error {
+	var err error
+	depth, ok := i.locals[i.encodeExpression(expr)]
 
 	if !ok {
-		assigned = i.globalEnvironment.assign(token.Lexeme, value)
+		err = i.globalEnvironment.assign(token.Lexeme, value)
 	} else {
-		assigned = i.environment.assignAt(token.Lexeme, value, depth)
+		err = i.environment.assignAt(token.Lexeme, value, depth)
 	}
 
-	if !assigned {
-		return nil, i.newError(token, fmt.Sprintf("Undefined variable '%s'.", token.Lexeme))
+	switch err {
+	case errUndefinedVariable:
+		return i.newError(token, fmt.Sprintf("Undefined variable '%s'.", token.Lexeme))
+	case errConstantAssignment:
+		return i.newError(token, fmt.Sprintf("Can't reassign constant '%s'.", token.Lexeme))
 	}
 
-	return value, nil
+	return nil
 }
 
 func (i *Interpreter) VisitLogicalExpr(expr parser.LogicalExpr) (any, error) {
@@ -196,20 +304,36 @@ func (i *Interpreter) VisitLogicalExpr(expr parser.LogicalExpr) (any, error) {
 		return nil, err
 	}
 
-	if expr.Operator.Type == scanner.OR {
-		if i.isTruthy(left) {
-			return left, nil
-		}
-	} else {
-		if !i.isTruthy(left) {
-			return left, nil
-		}
+	var shortCircuits bool
+	switch expr.Operator.Type {
+	case scanner.QUESTION_QUESTION:
+		shortCircuits = left != nil
+	case scanner.OR:
+		shortCircuits = i.isTruthy(left)
+	default:
+		shortCircuits = !i.isTruthy(left)
+	}
+
+	if i.tracer != nil {
+		i.tracer.Branch(expr, shortCircuits)
+	}
+
+	if shortCircuits {
+		return left, nil
 	}
 
 	return i.Evaluate(expr.Right)
 }
 
 func (i *Interpreter) VisitSetExpr(expr parser.SetExpr) (any, error) {
+	if expr.Name.Type == scanner.PRIVATE_IDENTIFIER {
+		value, err := i.Evaluate(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		return value, i.setPrivate(expr.Object, expr.Name, value)
+	}
+
 	object, err := i.Evaluate(expr.Object)
 	if err != nil {
 		return nil, err
@@ -220,29 +344,85 @@ func (i *Interpreter) VisitSetExpr(expr parser.SetExpr) (any, error) {
 		return nil, err
 	}
 
+	if err := i.setProperty(object, expr.Name, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// privateAccess returns the instance and the declaring class of the method that accesses the
+// private member name through 'this'. The class decides which private members are visible.
+func (i *Interpreter) privateAccess(object parser.Expr, name scanner.Token) (*loxInstance, *loxClass, error) {
+	if this, ok := object.(parser.ThisExpr); ok {
+		if distance, ok := i.locals[i.encodeExpression(this)]; ok {
+			env := i.environment.ancestor(distance)
+			instance, isInstance := env.values["this"].(*loxInstance)
+			owner, isOwned := env.values[ownerVariable].(*loxClass)
+
+			if isInstance && isOwned {
+				return instance, owner, nil
+			}
+		}
+	}
+
+	return nil, nil, i.newError(name, fmt.Sprintf("Private member '%s' can only be accessed through 'this' inside of its class.", name.Lexeme))
+}
+
+func (i *Interpreter) setPrivate(object parser.Expr, name scanner.Token, value any) error {
+	instance, owner, err := i.privateAccess(object, name)
+	if err != nil {
+		return err
+	}
+
+	if instance.frozen {
+		return i.newError(name, "Can't modify a frozen instance.")
+	}
+
+	instance.setPrivate(owner, name, value)
+
+	return nil
+}
+
+func (i *Interpreter) setProperty(object any, name scanner.Token, value any) error {
 	instance, ok := object.(loxAbstractInstance)
 	if !ok {
-		return nil, i.newError(expr.Name, "Only instances have properties.")
+		return i.newError(name, "Only instances have properties.")
 	}
 
-	instance.set(expr.Name, value)
+	var setter *loxFunction
+	switch object := object.(type) {
+	case *loxInstance:
+		if setter, ok = object.class.findSetter(name.Lexeme); ok {
+			setter = setter.bind(object)
+		}
+	case *loxClass:
+		setter, ok = object.findStaticSetter(name.Lexeme)
+	case *loxEnum, *loxEnumValue:
+		return i.newError(name, "Can't modify an enum or its values.")
+	}
 
-	return value, nil
+	if ok {
+		_, err := setter.call(i, []any{value}, name)
+		return err
+	}
+
+	if object, ok := object.(*loxInstance); ok && object.frozen {
+		return i.newError(name, "Can't modify a frozen instance.")
+	}
+
+	instance.set(name, value)
+
+	return nil
 }
 
 func (i *Interpreter) VisitArraySetExpr(expr parser.ArraySetExpr) (any, error) {
-	indexExpr, err := i.Evaluate(expr.Index)
+	index, err := i.Evaluate(expr.Index)
 	if err != nil {
 		return nil, err
 	}
 
-	index, ok := indexExpr.(float64)
-
-	if !ok || strings.Contains(i.Stringify(index), ".") {
-		return nil, i.newError(expr.Bracket, "Array indices should be an integer.")
-	}
-
-	arrayExpr, err := i.Evaluate(expr.Array)
+	array, err := i.Evaluate(expr.Array)
 	if err != nil {
 		return nil, err
 	}
@@ -252,20 +432,105 @@ func (i *Interpreter) VisitArraySetExpr(expr parser.ArraySetExpr) (any, error) {
 		return nil, err
 	}
 
-	array := arrayExpr.(*loxArray)
-	if err := array.validate(uint(index), expr.Bracket); err != nil {
+	if err := i.setIndex(array, index, expr.Bracket, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (i *Interpreter) arrayIndex(arrayValue any, indexValue any, bracket scanner.Token) (*loxArray, uint, error) {
+	index, ok := indexValue.(float64)
+
+	if !ok || strings.Contains(fmt.Sprintf("%v", index), ".") {
+		return nil, 0, i.newError(bracket, "Array indices should be an integer.")
+	}
+
+	array, ok := arrayValue.(*loxArray)
+	if !ok {
+		return nil, 0, i.newError(bracket, "Only arrays can be indexed.")
+	}
+
+	if err := array.validate(uint(index), bracket); err != nil {
+		return nil, 0, err
+	}
+
+	return array, uint(index), nil
+}
+
+func (i *Interpreter) rangeIndex(rangeValue *loxRange, indexValue any, bracket scanner.Token) (int, error) {
+	index, ok := indexValue.(float64)
+	if !ok || strings.Contains(fmt.Sprintf("%v", index), ".") {
+		return 0, i.newError(bracket, "Array indices should be an integer.")
+	}
+
+	if index < 0 || int(index) >= rangeValue.length() {
+		return 0, i.newError(bracket, "Array index is out of bounds.")
+	}
+
+	return int(index), nil
+}
+
+func (i *Interpreter) getIndex(arrayValue any, indexValue any, bracket scanner.Token) (any, error) {
+	if method, ok := i.specialMethod(arrayValue, "__index__"); ok {
+		return i.callSpecialMethod(method, bracket, indexValue)
+	}
+
+	// Ranges are indexed without materializing them.
+	if rangeValue, ok := arrayValue.(*loxRange); ok {
+		index, err := i.rangeIndex(rangeValue, indexValue, bracket)
+		if err != nil {
+			return nil, err
+		}
+		return rangeValue.at(index), nil
+	}
+
+	array, index, err := i.arrayIndex(arrayValue, indexValue, bracket)
+	if err != nil {
 		return nil, err
 	}
 
-	return array.set(uint(index), value), nil
+	return array.get(index), nil
+}
+
+func (i *Interpreter) setIndex(arrayValue any, indexValue any, bracket scanner.Token, value any) error {
+	if method, ok := i.specialMethod(arrayValue, "__setindex__"); ok {
+		_, err := i.callSpecialMethod(method, bracket, indexValue, value)
+		return err
+	}
+
+	if _, ok := arrayValue.(*loxRange); ok {
+		return i.newError(bracket, "Can't modify a range.")
+	}
+
+	array, index, err := i.arrayIndex(arrayValue, indexValue, bracket)
+	if err != nil {
+		return err
+	}
+
+	if array.frozen {
+		return i.newError(bracket, "Can't modify a frozen array.")
+	}
+
+	array.set(index, value)
+
+	return nil
 }
 
+// VisitSuperExpr looks a member up past the current class or trait. In instance methods of a
+// class the lookup starts at its superclass, in trait methods it continues after the trait in the
+// linearization of the instance's class, and in static methods it finds a static member of the
+// superclass.
 func (i *Interpreter) VisitSuperExpr(expr parser.SuperExpr) (any, error) {
 	distance := i.locals[i.encodeExpression(expr)]
-	super, _ := i.environment.getAt("super", distance)
-	this, instance := i.environment.getAt("this", distance-1)
+	env := i.environment.ancestor(distance)
+
+	if trait, ok := env.values[traitVariable].(*loxTrait); ok {
+		return i.traitSuper(expr, env, trait)
+	}
 
-	superclass := super.(*loxClass)
+	superclass := env.values["super"].(*loxClass)
+	this, instance := i.environment.ancestor(distance - 1).values["this"]
 
 	if instance {
 		method, ok := superclass.findMethod(expr.Method.Lexeme)
@@ -294,6 +559,25 @@ func (i *Interpreter) VisitSuperExpr(expr parser.SuperExpr) (any, error) {
 	return staticMethod, nil
 }
 
+func (i *Interpreter) traitSuper(expr parser.SuperExpr, env *environment, trait *loxTrait) (any, error) {
+	this := env.values["this"].(*loxInstance)
+
+	method, ok := this.class.nextMethod(trait, expr.Method.Lexeme)
+	if !ok {
+		return nil, i.newError(expr.Method, fmt.Sprintf("Undefined property '%s'.", expr.Method.Lexeme))
+	}
+
+	if method.owner == nil {
+		method.owner, _ = env.values[ownerVariable].(*loxClass)
+	}
+
+	if method.funStmt.Parameters == nil {
+		return method.bind(this).call(i, make([]any, 0), expr.Method)
+	}
+
+	return method.bind(this), nil
+}
+
 func (i *Interpreter) VisitBinaryExpr(binary parser.BinaryExpr) (any, error) {
 	obj1, err := i.Evaluate(binary.Left)
 	if err != nil {
@@ -307,6 +591,18 @@ func (i *Interpreter) VisitBinaryExpr(binary parser.BinaryExpr) (any, error) {
 
 	token := binary.Operator
 
+	if token.Type == scanner.IS {
+		return i.isInstance(obj1, obj2, token)
+	}
+
+	if result, ok, err := i.overloadedBinary(token, obj1, obj2); ok {
+		return result, err
+	}
+
+	if i.areBigNumberOperands(obj1, obj2) {
+		return i.bigNumberBinary(token, obj1, obj2)
+	}
+
 	switch binary.Operator.Type {
 	case scanner.PLUS:
 		if i.areNumberedOperands(obj1, obj2) {
@@ -373,11 +669,69 @@ func (i *Interpreter) VisitBinaryExpr(binary parser.BinaryExpr) (any, error) {
 	panic(i.newError(token, "Unreachable."))
 }
 
+func (i *Interpreter) bigNumberBinary(token scanner.Token, obj1 any, obj2 any) (any, error) {
+	switch token.Type {
+	case scanner.PLUS, scanner.MINUS, scanner.STAR, scanner.SLASH, scanner.MODULO:
+		return bigNumberArithmetic(token, obj1, obj2, i.decimalContext)
+	case scanner.EQUAL_EQUAL:
+		return i.areEqual(obj1, obj2), nil
+	case scanner.BANG_EQUAL:
+		return !i.areEqual(obj1, obj2), nil
+	}
+
+	result, err := compareBigNumbers(obj1, obj2, token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch token.Type {
+	case scanner.GREATER:
+		return result > 0, nil
+	case scanner.GREATER_EQUAL:
+		return result >= 0, nil
+	case scanner.LESS:
+		return result < 0, nil
+	case scanner.LESS_EQUAL:
+		return result <= 0, nil
+	}
+
+	panic(i.newError(token, "Unreachable."))
+}
+
+// isInstance reports whether value is an instance of class, or of a class using trait.
+func (i *Interpreter) isInstance(value any, target any, token scanner.Token) (any, error) {
+	instance, ok := value.(*loxInstance)
+
+	switch target := target.(type) {
+	case *loxClass:
+		return ok && instance.class.inherits(target), nil
+	case *loxTrait:
+		return ok && instance.class.implements(target), nil
+	case *loxEnum:
+		enumValue, ok := value.(*loxEnumValue)
+		return ok && enumValue.variant.enum == target, nil
+	case *loxEnumVariant:
+		enumValue, ok := value.(*loxEnumValue)
+		return ok && enumValue.variant == target, nil
+	}
+
+	return nil, i.newError(token, "Right operand of 'is' should be a class, a trait or an enum.")
+}
+
 func (i *Interpreter) VisitGroupingExpr(grouping parser.GroupingExpr) (any, error) {
 	return i.Evaluate(grouping.Expr)
 }
 
 func (i *Interpreter) VisitLiteralExpr(literal parser.LiteralExpr) (any, error) {
+	switch value := literal.Value.(type) {
+	case scanner.BigIntLiteral:
+		bigInt, _ := parseLoxBigInt(string(value))
+		return bigInt, nil
+	case scanner.DecimalLiteral:
+		decimal, _ := parseLoxDecimal(string(value))
+		return decimal, nil
+	}
+
 	return literal.Value, nil
 }
 
@@ -394,25 +748,69 @@ func (i *Interpreter) VisitUnaryExpr(unary parser.UnaryExpr) (any, error) {
 		if i.isType(obj, reflect.Float64) {
 			return -obj.(float64), nil
 		}
+		if isBigNumber(obj) {
+			return negateBigNumber(obj), nil
+		}
+		if method, ok := i.specialMethod(obj, "__neg__"); ok {
+			return i.callSpecialMethod(method, unary.Operator)
+		}
 	}
 	return nil, i.newError(unary.Operator, "Operand must be a number.")
 }
 
 func (i *Interpreter) VisitGetExpr(expr parser.GetExpr) (any, error) {
+	if expr.Name.Type == scanner.PRIVATE_IDENTIFIER {
+		instance, owner, err := i.privateAccess(expr.Object, expr.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := instance.getPrivate(owner, expr.Name)
+		if err != nil {
+			return nil, err
+		}
+		return i.callGetter(value, expr.Name)
+	}
+
 	object, err := i.Evaluate(expr.Object)
 	if err != nil {
 		return nil, err
 	}
+
+	if expr.Optional && object == nil {
+		return nil, errShortCircuit
+	}
+
+	return i.getProperty(object, expr.Name)
+}
+
+// VisitOptionalChainExpr evaluates a chain with optional links like "a?.b.c", which is nil as a
+// whole when an optional link finds nil.
+func (i *Interpreter) VisitOptionalChainExpr(expr parser.OptionalChainExpr) (any, error) {
+	value, err := i.Evaluate(expr.Expr)
+	if errors.Is(err, errShortCircuit) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+func (i *Interpreter) getProperty(object any, name scanner.Token) (any, error) {
 	instance, ok := object.(loxAbstractInstance)
 	if !ok {
-		return nil, i.newError(expr.Name, "Only instances have properties.")
+		return nil, i.newError(name, "Only instances have properties.")
 	}
 
-	value, err := instance.get(expr.Name)
+	value, err := instance.get(name)
 	if err != nil {
 		return nil, err
 	}
 
+	return i.callGetter(value, name)
+}
+
+// callGetter evaluates value when it is a getter method and returns any other value as it is.
+func (i *Interpreter) callGetter(value any, name scanner.Token) (any, error) {
 	fun, ok := value.(*loxFunction)
 
 	if !ok || !fun.isClassGetter {
@@ -426,7 +824,7 @@ func (i *Interpreter) VisitGetExpr(expr parser.GetExpr) (any, error) {
 
 	for _, stmt := range getterBody {
 		if _, ok := stmt.(parser.ReturnStmt); ok {
-			return fun.call(i, make([]any, 0), expr.Name)
+			return fun.call(i, make([]any, 0), name)
 		}
 	}
 
@@ -434,28 +832,21 @@ func (i *Interpreter) VisitGetExpr(expr parser.GetExpr) (any, error) {
 }
 
 func (i *Interpreter) VisitArrayGetExpr(expr parser.ArrayGetExpr) (any, error) {
-	indexExpr, err := i.Evaluate(expr.Index)
+	arrayValue, err := i.Evaluate(expr.Array)
 	if err != nil {
 		return nil, err
 	}
 
-	index, ok := indexExpr.(float64)
-
-	if !ok || strings.Contains(i.Stringify(index), ".") {
-		return nil, i.newError(expr.Bracket, "Array indices should be an integer.")
+	if expr.Optional && arrayValue == nil {
+		return nil, errShortCircuit
 	}
 
-	arrayExpr, err := i.Evaluate(expr.Array)
+	index, err := i.Evaluate(expr.Index)
 	if err != nil {
 		return nil, err
 	}
 
-	array := arrayExpr.(*loxArray)
-	if err := array.validate(uint(index), expr.Bracket); err != nil {
-		return nil, err
-	}
-
-	return array.get(uint(index)), nil
+	return i.getIndex(arrayValue, index, expr.Bracket)
 }
 
 func (i *Interpreter) VisitCallExpr(expr parser.CallExpr) (any, error) {
@@ -464,32 +855,128 @@ func (i *Interpreter) VisitCallExpr(expr parser.CallExpr) (any, error) {
 		return nil, err
 	}
 
+	if expr.Optional && callee == nil {
+		return nil, errShortCircuit
+	}
+
 	fun, ok := callee.(callable)
 
-	if !ok {
-		return nil, i.newError(expr.Parenthesis, "Non callable object, can only call functions and classes.")
+	if method, hasCall := i.specialMethod(callee, "__call__"); !ok && hasCall {
+		fun, ok = method, true
 	}
 
-	if fun.arity() != int32(len(expr.Arguments)) {
-		return nil, i.newError(expr.Parenthesis, fmt.Sprintf("Expected %d arguments, but got %d.", fun.arity(), len(expr.Arguments)))
+	if !ok {
+		return nil, i.newError(expr.Parenthesis, "Non callable object, can only call functions and classes.")
 	}
 
 	arguments := make([]any, 0)
 
 	for _, arg := range expr.Arguments {
+		spread, isSpread := arg.(parser.SpreadExpr)
+		if isSpread {
+			arg = spread.Expr
+		}
+
 		value, err := i.Evaluate(arg)
 		if err != nil {
 			return nil, err
 		}
-		arguments = append(arguments, value)
+
+		if !isSpread {
+			arguments = append(arguments, value)
+			continue
+		}
+
+		array, ok, err := i.asArray(value, spread.Ellipsis)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, i.newError(spread.Ellipsis, "Only arrays can be spread.")
+		}
+		arguments = append(arguments, array.elements...)
+	}
+
+	if len(expr.NamedArguments) != 0 {
+		if arguments, err = i.bindNamedArguments(fun, arguments, expr.NamedArguments, expr.Parenthesis); err != nil {
+			return nil, err
+		}
+	}
+
+	minArity, maxArity := fun.arity()
+	count := int32(len(arguments))
+
+	if minArity == maxArity && count != minArity {
+		return nil, i.newError(expr.Parenthesis, fmt.Sprintf("Expected %d arguments, but got %d.", minArity, count))
+	}
+
+	if count < minArity {
+		return nil, i.newError(expr.Parenthesis, fmt.Sprintf("Expected at least %d arguments, but got %d.", minArity, count))
+	}
+
+	if maxArity != variadic && count > maxArity {
+		return nil, i.newError(expr.Parenthesis, fmt.Sprintf("Expected at most %d arguments, but got %d.", maxArity, count))
 	}
 
 	return fun.call(i, arguments, expr.Parenthesis)
 }
 
+// bindNamedArguments places named arguments at the positions of their parameters. Skipped
+// parameters are filled with missingArgument, so they get their default value.
+func (i *Interpreter) bindNamedArguments(fun callable, arguments []any, namedArguments []parser.NamedArgument, parenthesis scanner.Token) ([]any, error) {
+	function, ok := fun.(parameterized)
+	if _, partial := fun.(*partialFunction); partial {
+		return nil, i.newError(parenthesis, "Partially applied functions don't accept named arguments.")
+	} else if !ok {
+		return nil, i.newError(parenthesis, "Native functions don't accept named arguments.")
+	}
+	parameters := function.parameters()
+
+	for _, named := range namedArguments {
+		idx := slices.IndexFunc(parameters, func(parameter parser.Parameter) bool {
+			return parameter.Pattern == nil && parameter.Name.Lexeme == named.Name.Lexeme
+		})
+
+		if idx == -1 || parameters[idx].Rest {
+			return nil, i.newError(named.Name, fmt.Sprintf("Unknown parameter '%s'.", named.Name.Lexeme))
+		}
+
+		for len(arguments) <= idx {
+			arguments = append(arguments, missingArgument{})
+		}
+
+		if _, missing := arguments[idx].(missingArgument); !missing {
+			return nil, i.newError(named.Name, fmt.Sprintf("Argument for parameter '%s' was passed more than once.", named.Name.Lexeme))
+		}
+
+		value, err := i.Evaluate(named.Value)
+		if err != nil {
+			return nil, err
+		}
+		arguments[idx] = value
+	}
+
+	for idx, parameter := range parameters {
+		if parameter.Default != nil || parameter.Rest {
+			continue
+		}
+
+		missing := idx >= len(arguments)
+		if !missing {
+			_, missing = arguments[idx].(missingArgument)
+		}
+
+		if missing {
+			return nil, i.newError(parenthesis, fmt.Sprintf("Missing argument for parameter '%s'.", parameter.Name.Lexeme))
+		}
+	}
+
+	return arguments, nil
+}
+
 func (i *Interpreter) VisitLambdaExpr(expr parser.LambdaExpr) (any, error) {
 	name := scanner.Token{Type: scanner.IDENTIFIER, Lexeme: "lambda", Literal: nil, Line: expr.Parenthesis.Line}
-	return newLoxFunction(parser.FunctionStmt{Name: name, Parameters: expr.Parameters, Body: expr.Body}, i.environment), nil
+	return newLoxFunction(parser.FunctionStmt{Name: name, Parameters: expr.Parameters, ReturnType: expr.ReturnType, Body: expr.Body}, i.environment), nil
 }
 
 func (i *Interpreter) VisitThisExpr(expr parser.ThisExpr) (any, error) {
@@ -506,6 +993,52 @@ func (i *Interpreter) VisitVariableExpr(variableExpr parser.VariableExpr) (any,
 	return value, nil
 }
 
+func (i *Interpreter) VisitMatchExpr(expr parser.MatchExpr) (any, error) {
+	subject, err := i.Evaluate(expr.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	for _, matchCase := range expr.Cases {
+		i.environment = newEnvironment(previous)
+
+		matched, err := i.matchPattern(matchCase.Pattern, subject, i.environment)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched && matchCase.Guard != nil {
+			guard, err := i.Evaluate(matchCase.Guard)
+			if err != nil {
+				return nil, err
+			}
+			matched = i.isTruthy(guard)
+		}
+
+		if !matched {
+			continue
+		}
+
+		if matchCase.Block != nil {
+			return i.executeBlock(matchCase.Block, newEnvironment(i.environment))
+		}
+
+		return i.Evaluate(matchCase.Body)
+	}
+
+	text, err := i.Stringify(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, i.newError(expr.Keyword, fmt.Sprintf("No match case for value '%s'.", text))
+}
+
 func (i *Interpreter) VisitExpressionStmt(expressionStmt parser.ExpressionStmt) (any, error) {
 	_, err := i.Evaluate(expressionStmt.Expression)
 	if err != nil {
@@ -519,10 +1052,35 @@ func (i *Interpreter) VisitPrintStmt(stmt parser.PrintStmt) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println(i.Stringify(value))
+	text, err := i.Stringify(value)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(text)
 	return nil, nil
 }
 
+func (i *Interpreter) VisitAssertStmt(stmt parser.AssertStmt) (any, error) {
+	condition, err := i.Evaluate(stmt.Condition)
+	if err != nil || i.isTruthy(condition) {
+		return nil, err
+	}
+
+	// The message is only evaluated when the assertion fails.
+	message := ""
+	if stmt.Message != nil {
+		value, err := i.Evaluate(stmt.Message)
+		if err != nil {
+			return nil, err
+		}
+		if message, err = i.Stringify(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, &AssertionError{Token: stmt.Keyword, Message: message}
+}
+
 func (i *Interpreter) VisitVarStmt(varStmt parser.VarStmt) (any, error) {
 	var value any = nil
 	var err error = nil
@@ -534,13 +1092,51 @@ func (i *Interpreter) VisitVarStmt(varStmt parser.VarStmt) (any, error) {
 		}
 	}
 
-	i.environment.define(varStmt.Name.Lexeme, value)
+	if varStmt.Constant {
+		err = i.environment.defineConstant(varStmt.Name.Lexeme, value)
+	} else {
+		err = i.environment.define(varStmt.Name.Lexeme, value)
+	}
+
+	if err != nil {
+		return nil, i.redeclarationError(varStmt.Name)
+	}
 	return nil, nil
 }
 
+// define binds name in env like environment.define, turning the redeclaration of a constant into
+// a runtime error.
+func (i *Interpreter) define(env *environment, name scanner.Token, value any) error {
+	if err := env.define(name.Lexeme, value); err != nil {
+		return i.redeclarationError(name)
+	}
+
+	return nil
+}
+
+func (i *Interpreter) redeclarationError(name scanner.Token) error {
+	return i.newError(name, fmt.Sprintf("Can't redeclare constant '%s'.", name.Lexeme))
+}
+
+func (i *Interpreter) VisitDestructureStmt(stmt parser.DestructureStmt) (any, error) {
+	value, err := i.Evaluate(stmt.Initializer)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, i.destructure(stmt.Pattern, value, i.environment)
+}
+
 func (i *Interpreter) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 	className := stmt.Name.Lexeme
-	i.environment.define(className, nil)
+	if err := i.define(i.environment, stmt.Name, nil); err != nil {
+		return nil, err
+	}
+
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
 
 	superclassExists := !reflect.ValueOf(stmt.Superclass).IsZero()
 	var superclass *loxClass
@@ -563,52 +1159,207 @@ func (i *Interpreter) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 	}
 
 	methods, staticMethods := make(map[string]*loxFunction), make(map[string]*loxFunction)
+	setters, staticSetters := make(map[string]*loxFunction), make(map[string]*loxFunction)
+	members, err := i.composeTraits(stmt.Traits)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, classTrait := range stmt.Traits {
-		traitStmt, err := i.Evaluate(classTrait)
-		if err != nil {
-			return nil, err
-		}
+	members.resolveConflicts(stmt.Methods, stmt.StaticMethods, stmt.Setters, stmt.StaticSetters)
+	if err := members.checkConflicts(stmt.Name); err != nil {
+		return nil, err
+	}
 
-		trait, ok := traitStmt.(*loxTrait)
-		if !ok {
-			return nil, i.newError(classTrait.Name, fmt.Sprintf("'%s' is not a trait.", classTrait.Name.Lexeme))
+	declared := make(map[string]bool)
+	for _, method := range slices.Concat(stmt.Methods, stmt.AbstractMethods) {
+		declared[method.Name.Lexeme] = true
+	}
+	missing := members.unimplemented(func(name string) bool {
+		if declared[name] || superclass == nil {
+			return declared[name]
 		}
+		_, ok := superclass.findMethod(name)
+		return ok
+	})
+	if len(missing) > 0 {
+		return nil, i.newError(stmt.Name, fmt.Sprintf("Class '%s' must implement method '%s' required by trait '%s'.", className, missing[0].name, missing[0].origin.Name().Lexeme))
+	}
 
-		for _, method := range trait.Methods() {
-			methods[method.Name.Lexeme] = newLoxFunction(method, i.environment)
-		}
+	// Trait members close over the environment of their trait, where they were resolved.
+	for name, member := range members.methods.members {
+		methods[name] = member.origin.method(member.stmt)
+	}
 
-		for _, method := range trait.StaticMethods() {
-			staticMethods[method.Name.Lexeme] = newLoxStaticMethod(method, i.environment)
-		}
+	for name, member := range members.staticMethods.members {
+		staticMethods[name] = newLoxStaticMethod(member.stmt, newEnvironment(member.origin.closure))
 	}
 
+	for name, member := range members.setters.members {
+		setters[name] = member.origin.method(member.stmt)
+	}
+
+	for name, member := range members.staticSetters.members {
+		staticSetters[name] = newLoxStaticMethod(member.stmt, newEnvironment(member.origin.closure))
+	}
+
+	fields := slices.Concat(members.fields, stmt.Fields)
+	staticFields := slices.Concat(members.staticFields, stmt.StaticFields)
+
 	for _, method := range stmt.Methods {
 		methods[method.Name.Lexeme] = newLoxMethod(method, i.environment)
 	}
 
+	// Static methods aren't bound, so they get an empty environment in place of the one holding
+	// 'this', to stay at the depth the resolver expects.
+	staticClosure := newEnvironment(i.environment)
 	for _, method := range stmt.StaticMethods {
-		staticMethods[method.Name.Lexeme] = newLoxStaticMethod(method, i.environment)
+		staticMethods[method.Name.Lexeme] = newLoxStaticMethod(method, staticClosure)
+	}
+
+	for _, setter := range stmt.Setters {
+		setters[setter.Name.Lexeme] = newLoxFunction(setter, i.environment)
+	}
+
+	for _, setter := range stmt.StaticSetters {
+		staticSetters[setter.Name.Lexeme] = newLoxStaticMethod(setter, staticClosure)
 	}
 
+	closure := i.environment
 	if superclassExists {
 		i.environment = i.environment.enclosing
 	}
 
-	i.environment.assign(className, newMetaClass(stmt, methods, staticMethods).NewClass(superclass))
+	class := newMetaClass(stmt, methods, staticMethods, setters, staticSetters, fields, closure, members.traits).NewClass(superclass)
+	for _, method := range methods {
+		method.owner = class
+	}
+	for _, setter := range setters {
+		setter.owner = class
+	}
+
+	if !stmt.Abstract {
+		if method, owner, ok := class.unimplementedMethod(); ok {
+			return nil, i.newError(stmt.Name, fmt.Sprintf("Class '%s' must implement abstract method '%s' of class '%s'.", className, method.Lexeme, owner.metaClass.stmt.Name.Lexeme))
+		}
+	}
+
+	i.environment.assign(className, class)
+
+	// Static fields are initialized once the class exists, so they can refer to it.
+	env := newEnvironment(closure)
+	for _, field := range staticFields {
+		value, err := i.evaluateField(field, env)
+		if err != nil {
+			return nil, err
+		}
+		class.staticFields[field.Name.Lexeme] = value
+	}
 
 	return nil, nil
 }
 
+func (i *Interpreter) evaluateField(field parser.VarStmt, env *environment) (any, error) {
+	if field.Initializer == nil {
+		return nil, nil
+	}
+
+	previous := i.environment
+	i.environment = env
+	defer func() {
+		i.environment = previous
+	}()
+
+	return i.Evaluate(field.Initializer)
+}
+
 func (i *Interpreter) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
-	i.environment.define(stmt.Name.Lexeme, newTrait(stmt))
-	return nil, nil
+	members, err := i.composeTraits(stmt.Traits)
+	if err != nil {
+		return nil, err
+	}
+
+	trait := newTrait(stmt, members, i.environment)
+	own := func(set *memberSet, declared []parser.FunctionStmt) {
+		for _, member := range declared {
+			set.override(member.Name.Lexeme, traitMember{stmt: member, origin: trait, name: member.Name.Lexeme, via: stmt.Name})
+		}
+	}
+	own(members.methods, stmt.Methods)
+	own(members.staticMethods, stmt.StaticMethods)
+	own(members.setters, stmt.Setters)
+	own(members.staticSetters, stmt.StaticSetters)
+
+	members.fields = slices.Concat(members.fields, stmt.Fields)
+	members.staticFields = slices.Concat(members.staticFields, stmt.StaticFields)
+
+	if err := members.checkConflicts(stmt.Name); err != nil {
+		return nil, err
+	}
+
+	// Requirements met by the composed traits are dropped, the rest pass on to the host class.
+	for _, method := range stmt.Required {
+		members.required[method.Name.Lexeme] = traitMember{stmt: method, origin: trait, name: method.Name.Lexeme, via: stmt.Name}
+	}
+	required := make(map[string]traitMember)
+	for _, member := range members.unimplemented(func(string) bool { return false }) {
+		required[member.name] = member
+	}
+	members.required = required
+
+	return nil, i.define(i.environment, stmt.Name, trait)
+}
+
+// composeTraits collects the members of the traits a class or trait uses. Conflicts between them
+// are only recorded, since the declaration can still resolve them by declaring the member itself.
+func (i *Interpreter) composeTraits(uses []parser.TraitUse) (*traitMembers, error) {
+	members := newTraitMembers()
+
+	for _, use := range uses {
+		value, err := i.Evaluate(use.Trait)
+		if err != nil {
+			return nil, err
+		}
+
+		trait, ok := value.(*loxTrait)
+		if !ok {
+			return nil, i.newError(use.Trait.Name, fmt.Sprintf("'%s' is not a trait.", use.Trait.Name.Lexeme))
+		}
+
+		adapted, err := trait.adapt(use)
+		if err != nil {
+			return nil, err
+		}
+
+		members.include(adapted)
+		members.traits = append(members.traits, trait)
+	}
+
+	return members, nil
+}
+
+func (i *Interpreter) VisitEnumStmt(stmt parser.EnumStmt) (any, error) {
+	methods, staticMethods := make(map[string]*loxFunction), make(map[string]*loxFunction)
+
+	for _, method := range stmt.Methods {
+		methods[method.Name.Lexeme] = newLoxMethod(method, i.environment)
+	}
+
+	// As in classes, static methods get an empty environment in place of the one holding 'this'.
+	staticClosure := newEnvironment(i.environment)
+	for _, method := range stmt.StaticMethods {
+		staticMethods[method.Name.Lexeme] = newLoxStaticMethod(method, staticClosure)
+	}
+
+	enum := newLoxEnum(stmt, methods, staticMethods)
+	for ordinal, variant := range stmt.Variants {
+		enum.variants = append(enum.variants, newLoxEnumVariant(enum, variant, ordinal, i.environment))
+	}
+
+	return nil, i.define(i.environment, stmt.Name, enum)
 }
 
 func (i *Interpreter) VisitFunctionStmt(stmt parser.FunctionStmt) (any, error) {
-	i.environment.define(stmt.Name.Lexeme, newLoxFunction(stmt, i.environment))
-	return nil, nil
+	return nil, i.define(i.environment, stmt.Name, newLoxFunction(stmt, i.environment))
 }
 
 func (i *Interpreter) VisitBlockStmt(stmt parser.BlockStmt) (any, error) {
@@ -621,7 +1372,12 @@ func (i *Interpreter) VisitIfStmt(stmt parser.IfStmt) (any, error) {
 		return nil, err
 	}
 
-	if i.isTruthy(value) {
+	truthy := i.isTruthy(value)
+	if i.tracer != nil {
+		i.tracer.Branch(stmt, truthy)
+	}
+
+	if truthy {
 		value, err = i.execute(stmt.ThenBranch)
 	} else if stmt.ElseBranch != nil {
 		value, err = i.execute(stmt.ElseBranch)
@@ -642,13 +1398,9 @@ func (i *Interpreter) VisitWhileStmt(stmt parser.WhileStmt) (any, error) {
 
 	for i.isTruthy(condition) {
 		if _, err := i.execute(stmt.Body); err != nil {
-			if errors.Is(err, &parser.BreakInterrupt{}) {
-				break
-			}
-			if errors.Is(err, &parser.ContinueInterrupt{}) {
-				continue
+			if stop, err := i.loopInterrupt(err, stmt.Label); stop || err != nil {
+				return nil, err
 			}
-			return nil, err
 		}
 
 		condition, _ = i.Evaluate(stmt.Condition)
@@ -656,6 +1408,42 @@ func (i *Interpreter) VisitWhileStmt(stmt parser.WhileStmt) (any, error) {
 	return nil, nil
 }
 
+func (i *Interpreter) VisitDoWhileStmt(stmt parser.DoWhileStmt) (any, error) {
+	for {
+		if _, err := i.execute(stmt.Body); err != nil {
+			if stop, err := i.loopInterrupt(err, stmt.Label); stop || err != nil {
+				return nil, err
+			}
+		}
+
+		condition, err := i.Evaluate(stmt.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		if !i.isTruthy(condition) {
+			return nil, nil
+		}
+	}
+}
+
+// loopInterrupt handles an error returned by the body of the loop labeled label. A break or
+// continue aimed at the loop is consumed, and stop tells whether the loop has to end. Any other
+// error is returned, so it keeps unwinding.
+func (i *Interpreter) loopInterrupt(err error, label scanner.Token) (bool, error) {
+	breakInterrupt := &parser.BreakInterrupt{}
+	if errors.As(err, &breakInterrupt) && breakInterrupt.Targets(label.Lexeme) {
+		return true, nil
+	}
+
+	continueInterrupt := &parser.ContinueInterrupt{}
+	if errors.As(err, &continueInterrupt) && continueInterrupt.Targets(label.Lexeme) {
+		return false, nil
+	}
+
+	return true, err
+}
+
 func (i *Interpreter) VisitForStmt(stmt parser.ForStmt) (any, error) {
 	initializer, forCondition := stmt.Initializer, stmt.Condition
 
@@ -686,16 +1474,9 @@ func (i *Interpreter) VisitForStmt(stmt parser.ForStmt) (any, error) {
 
 	for i.isTruthy(condition) {
 		if _, err := i.execute(stmt.Body); err != nil {
-			if errors.Is(err, &parser.BreakInterrupt{}) {
-				break
-			}
-			if errors.Is(err, &parser.ContinueInterrupt{}) {
-				if err := evaluateLoop(); err != nil {
-					return nil, err
-				}
-				continue
+			if stop, err := i.loopInterrupt(err, stmt.Label); stop || err != nil {
+				return nil, err
 			}
-			return nil, err
 		}
 
 		if err := evaluateLoop(); err != nil {
@@ -705,12 +1486,60 @@ func (i *Interpreter) VisitForStmt(stmt parser.ForStmt) (any, error) {
 	return nil, nil
 }
 
-func (i *Interpreter) VisitBreakStmt(_ parser.BreakStmt) (any, error) {
-	return nil, &parser.BreakInterrupt{}
+// VisitSwitchStmt runs the first case with a value equal to the subject, or the default case when
+// none matches. Values are evaluated in order until one matches, and cases don't fall through.
+func (i *Interpreter) VisitSwitchStmt(stmt parser.SwitchStmt) (any, error) {
+	subject, err := i.Evaluate(stmt.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := i.switchCaseBody(stmt, subject)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	_, err = i.executeBlock(body, newEnvironment(i.environment))
+
+	// An unlabeled break leaves the switch, while continue and labeled breaks reach the loops.
+	breakInterrupt := &parser.BreakInterrupt{}
+	if errors.As(err, &breakInterrupt) && breakInterrupt.Label == "" {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (i *Interpreter) switchCaseBody(stmt parser.SwitchStmt, subject any) ([]parser.Stmt, error) {
+	for _, switchCase := range stmt.Cases {
+		// Cases are compared with "==", so they call '__eq__' like it does.
+		token := scanner.Token{Type: scanner.EQUAL_EQUAL, Lexeme: "==", Line: switchCase.Keyword.Line}
+
+		for _, valueExpr := range switchCase.Values {
+			value, err := i.Evaluate(valueExpr)
+			if err != nil {
+				return nil, err
+			}
+
+			equal, err := i.equal(token, subject, value)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				return switchCase.Body, nil
+			}
+		}
+	}
+
+	return stmt.Default, nil
 }
 
-func (i *Interpreter) VisitContinueStmt(_ parser.ContinueStmt) (any, error) {
-	return nil, &parser.ContinueInterrupt{}
+func (i *Interpreter) VisitBreakStmt(stmt parser.BreakStmt) (any, error) {
+	return nil, &parser.BreakInterrupt{Label: stmt.Label.Lexeme}
+}
+
+func (i *Interpreter) VisitContinueStmt(stmt parser.ContinueStmt) (any, error) {
+	return nil, &parser.ContinueInterrupt{Label: stmt.Label.Lexeme}
 }
 
 func (i *Interpreter) VisitReturnStmt(stmt parser.ReturnStmt) (any, error) {
@@ -728,6 +1557,32 @@ func (i *Interpreter) VisitReturnStmt(stmt parser.ReturnStmt) (any, error) {
 	return nil, &parser.ReturnInterrupt{Value: value}
 }
 
+// CallGlobal calls the global function with the given name without arguments. Errors about the
+// call itself are reported on the line declaring the function.
+func (i *Interpreter) CallGlobal(name string) (any, error) {
+	token := scanner.Token{Type: scanner.IDENTIFIER, Lexeme: name}
+
+	value, ok := i.globalEnvironment.get(name)
+	if !ok {
+		return nil, i.newError(token, fmt.Sprintf("Undefined variable '%s'.", name))
+	}
+
+	function, ok := value.(callable)
+	if !ok {
+		return nil, i.newError(token, fmt.Sprintf("'%s' is not a function.", name))
+	}
+
+	if function, ok := function.(*loxFunction); ok {
+		token.Line = function.funStmt.Name.Line
+	}
+
+	if minArity, _ := function.arity(); minArity != 0 {
+		return nil, i.newError(token, fmt.Sprintf("'%s' can't be called without arguments, it has %d required parameters.", name, minArity))
+	}
+
+	return function.call(i, make([]any, 0), token)
+}
+
 func (i *Interpreter) Interpret(statements []parser.Stmt) error {
 	for _, stmt := range statements {
 		if _, err := i.execute(stmt); err != nil {
@@ -0,0 +1,106 @@
+package interpreter
+
+import "glox/parser"
+
+// linearizationEntry is a class or a trait in a method resolution order. excluded holds the
+// members the declaration using a trait left out of it.
+type linearizationEntry struct {
+	class    *loxClass
+	trait    *loxTrait
+	excluded map[string]bool
+}
+
+// linearization returns the method resolution order of the class: the class itself, its traits
+// from the last one listed to the first, each followed by the traits it uses, and then the
+// linearization of its superclass. A trait reached more than once only keeps its last position,
+// so it comes after every class and trait that uses it.
+func (c *loxClass) linearization() []linearizationEntry {
+	entries := []linearizationEntry{{class: c}}
+	entries = append(entries, traitLinearization(c.metaClass.stmt.Traits, c.metaClass.traits)...)
+
+	if c.superclass != nil {
+		entries = append(entries, c.superclass.linearization()...)
+	}
+
+	linearization := make([]linearizationEntry, 0, len(entries))
+	for i, entry := range entries {
+		if entry.trait != nil && lastTraitPosition(entries, entry.trait) != i {
+			continue
+		}
+		linearization = append(linearization, entry)
+	}
+
+	return linearization
+}
+
+func traitLinearization(uses []parser.TraitUse, traits []*loxTrait) []linearizationEntry {
+	entries := make([]linearizationEntry, 0)
+
+	for i := len(traits) - 1; i >= 0; i-- {
+		excluded := make(map[string]bool)
+		for _, member := range uses[i].Excluded {
+			excluded[member.Lexeme] = true
+		}
+
+		entries = append(entries, linearizationEntry{trait: traits[i], excluded: excluded})
+		entries = append(entries, traitLinearization(traits[i].stmt.Traits, traits[i].members.traits)...)
+	}
+
+	return entries
+}
+
+func lastTraitPosition(entries []linearizationEntry, trait *loxTrait) int {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].trait == trait {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// nextMethod finds the implementation of name that comes after trait in the method resolution
+// order of class. Only members declared by each class or trait count, as traits have their own
+// entries in the order.
+func (c *loxClass) nextMethod(trait *loxTrait, name string) (*loxFunction, bool) {
+	linearization := c.linearization()
+
+	start := len(linearization)
+	for i, entry := range linearization {
+		if entry.trait == trait {
+			start = i + 1
+			break
+		}
+	}
+
+	for _, entry := range linearization[start:] {
+		if entry.class != nil {
+			if declaresMethod(entry.class.metaClass.stmt.Methods, name) {
+				return entry.class.metaClass.methods[name], true
+			}
+			continue
+		}
+
+		if entry.excluded[name] {
+			continue
+		}
+
+		for _, method := range entry.trait.stmt.Methods {
+			if method.Name.Lexeme == name {
+				return entry.trait.method(method), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func declaresMethod(methods []parser.FunctionStmt, name string) bool {
+	for _, method := range methods {
+		if method.Name.Lexeme == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,15 +1,19 @@
 package interpreter
 
 import (
+	"fmt"
 	"glox/scanner"
+	"math"
+	"math/big"
+	"slices"
 	"time"
 )
 
 type nativeClock struct {
 }
 
-func (n *nativeClock) arity() int32 {
-	return 0
+func (n *nativeClock) arity() (int32, int32) {
+	return 0, 0
 }
 
 func (n *nativeClock) call(*Interpreter, []any, scanner.Token) (any, error) {
@@ -23,12 +27,12 @@ func (n *nativeClock) String() string {
 type nativeStringify struct {
 }
 
-func (n *nativeStringify) arity() int32 {
-	return 1
+func (n *nativeStringify) arity() (int32, int32) {
+	return 1, 1
 }
 
 func (n *nativeStringify) call(i *Interpreter, arguments []any, _ scanner.Token) (any, error) {
-	return i.Stringify(arguments[0]), nil
+	return i.Stringify(arguments[0])
 }
 
 func (n *nativeStringify) String() string {
@@ -38,12 +42,15 @@ func (n *nativeStringify) String() string {
 type nativeAppend struct {
 }
 
-func (n *nativeAppend) arity() int32 {
-	return 2
+func (n *nativeAppend) arity() (int32, int32) {
+	return 2, 2
 }
 
-func (n *nativeAppend) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
-	array, ok := arguments[0].(*loxArray)
+func (n *nativeAppend) call(interpreter *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	array, ok, err := interpreter.asArray(arguments[0], token)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		return nil, &Error{Token: token, Message: "First argument to 'append' should be an array."}
 	}
@@ -58,11 +65,15 @@ func (n *nativeAppend) String() string {
 type nativeLen struct {
 }
 
-func (n *nativeLen) arity() int32 {
-	return 1
+func (n *nativeLen) arity() (int32, int32) {
+	return 1, 1
 }
 
 func (n *nativeLen) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	if rangeValue, ok := arguments[0].(*loxRange); ok {
+		return float64(rangeValue.length()), nil
+	}
+
 	array, ok := arguments[0].(*loxArray)
 	if !ok {
 		return nil, &Error{Token: token, Message: "First argument to 'len' should be an array."}
@@ -74,3 +85,216 @@ func (n *nativeLen) call(_ *Interpreter, arguments []any, token scanner.Token) (
 func (n *nativeLen) String() string {
 	return "<native fn>"
 }
+
+type nativeBigInt struct {
+}
+
+func (n *nativeBigInt) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeBigInt) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	switch value := arguments[0].(type) {
+	case *loxBigInt:
+		return value, nil
+	case *loxDecimal:
+		return newLoxBigInt(value.rescale(0, roundingDown).unscaled), nil
+	case float64:
+		if value == math.Trunc(value) && !math.IsInf(value, 0) {
+			bigValue, _ := big.NewFloat(value).Int(nil)
+			return newLoxBigInt(bigValue), nil
+		}
+	case string:
+		if bigInt, ok := parseLoxBigInt(value); ok {
+			return bigInt, nil
+		}
+	}
+
+	return nil, &Error{Token: token, Message: "Argument to 'bigint' should be an integer or an integer string."}
+}
+
+func (n *nativeBigInt) String() string {
+	return "<native fn>"
+}
+
+type nativeDecimal struct {
+}
+
+func (n *nativeDecimal) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeDecimal) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	switch value := arguments[0].(type) {
+	case *loxBigInt:
+		return decimalFromBigInt(value), nil
+	case *loxDecimal:
+		return value, nil
+	case float64:
+		if decimal, ok := decimalFromFloat(value); ok {
+			return decimal, nil
+		}
+	case string:
+		if decimal, ok := parseLoxDecimal(value); ok {
+			return decimal, nil
+		}
+	}
+
+	return nil, &Error{Token: token, Message: "Argument to 'decimal' should be a number or a numeric string."}
+}
+
+func (n *nativeDecimal) String() string {
+	return "<native fn>"
+}
+
+type nativeDecimalContext struct {
+}
+
+func (n *nativeDecimalContext) arity() (int32, int32) {
+	return 2, 2
+}
+
+func (n *nativeDecimalContext) call(i *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	precision, ok := arguments[0].(float64)
+	if !ok || precision < 0 || precision != math.Trunc(precision) {
+		return nil, &Error{Token: token, Message: "First argument to 'decimalContext' should be a non-negative integer."}
+	}
+
+	rounding, ok := arguments[1].(string)
+	if !ok || !roundingModes[rounding] {
+		return nil, &Error{Token: token, Message: "Second argument to 'decimalContext' should be a rounding mode: up, down, ceiling, floor, half_up, half_down or half_even."}
+	}
+
+	i.decimalContext = decimalContext{precision: int32(precision), rounding: rounding}
+
+	return nil, nil
+}
+
+func (n *nativeDecimalContext) String() string {
+	return "<native fn>"
+}
+
+type nativeRound struct {
+}
+
+func (n *nativeRound) arity() (int32, int32) {
+	return 2, 2
+}
+
+func (n *nativeRound) call(i *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	places, ok := arguments[1].(float64)
+	if !ok || places < 0 || places != math.Trunc(places) {
+		return nil, &Error{Token: token, Message: "Second argument to 'round' should be a non-negative integer."}
+	}
+
+	switch value := arguments[0].(type) {
+	case *loxBigInt:
+		return value, nil
+	case *loxDecimal:
+		return value.rescale(int32(places), i.decimalContext.rounding), nil
+	case float64:
+		if decimal, ok := decimalFromFloat(value); ok {
+			return decimal.rescale(int32(places), i.decimalContext.rounding).float(), nil
+		}
+		return value, nil
+	}
+
+	return nil, &Error{Token: token, Message: "First argument to 'round' should be a number."}
+}
+
+func (n *nativeRound) String() string {
+	return "<native fn>"
+}
+
+type nativeInspect struct {
+}
+
+func (n *nativeInspect) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeInspect) call(i *Interpreter, arguments []any, _ scanner.Token) (any, error) {
+	return i.inspect(arguments[0], make(map[any]bool))
+}
+
+func (n *nativeInspect) String() string {
+	return "<native fn>"
+}
+
+type nativeFreeze struct {
+}
+
+func (n *nativeFreeze) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeFreeze) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	switch value := arguments[0].(type) {
+	case *loxInstance:
+		value.frozen = true
+	case *loxArray:
+		value.frozen = true
+	default:
+		return nil, &Error{Token: token, Message: "Argument to 'freeze' should be an instance or an array."}
+	}
+
+	return arguments[0], nil
+}
+
+func (n *nativeFreeze) String() string {
+	return "<native fn>"
+}
+
+type nativeBind struct {
+}
+
+func (n *nativeBind) arity() (int32, int32) {
+	return 1, variadic
+}
+
+func (n *nativeBind) call(interpreter *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	fun, ok := arguments[0].(callable)
+	if method, hasCall := interpreter.specialMethod(arguments[0], "__call__"); !ok && hasCall {
+		fun, ok = method, true
+	}
+
+	if !ok {
+		return nil, &Error{Token: token, Message: "First argument to 'bind' should be callable."}
+	}
+
+	bound := arguments[1:]
+	if _, maxArity := fun.arity(); maxArity != variadic && int32(len(bound)) > maxArity {
+		return nil, &Error{Token: token, Message: fmt.Sprintf("Can't bind %d arguments to a function that takes at most %d.", len(bound), maxArity)}
+	}
+
+	return &partialFunction{function: fun, bound: bound}, nil
+}
+
+func (n *nativeBind) String() string {
+	return "<native fn>"
+}
+
+// partialFunction is a callable with its leading arguments fixed by 'bind'.
+type partialFunction struct {
+	function callable
+	bound    []any
+}
+
+func (p *partialFunction) arity() (int32, int32) {
+	minArity, maxArity := p.function.arity()
+	count := int32(len(p.bound))
+
+	if maxArity != variadic {
+		maxArity -= count
+	}
+
+	return max(minArity-count, 0), maxArity
+}
+
+func (p *partialFunction) call(interpreter *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	return p.function.call(interpreter, slices.Concat(p.bound, arguments), token)
+}
+
+func (p *partialFunction) String() string {
+	return "<partial fn>"
+}
@@ -0,0 +1,102 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/scanner"
+)
+
+var operatorMethods = map[scanner.TokenType]string{
+	scanner.PLUS:          "__add__",
+	scanner.MINUS:         "__sub__",
+	scanner.STAR:          "__mul__",
+	scanner.SLASH:         "__div__",
+	scanner.MODULO:        "__mod__",
+	scanner.LESS:          "__lt__",
+	scanner.LESS_EQUAL:    "__le__",
+	scanner.GREATER:       "__gt__",
+	scanner.GREATER_EQUAL: "__ge__",
+	scanner.EQUAL_EQUAL:   "__eq__",
+	scanner.BANG_EQUAL:    "__eq__",
+}
+
+// reflectedOperatorMethods are called on the right operand when the left one doesn't overload the
+// operator. Comparisons are reflected by swapping the operands, e.g. "a < b" becomes "b > a".
+var reflectedOperatorMethods = map[scanner.TokenType]string{
+	scanner.PLUS:          "__radd__",
+	scanner.MINUS:         "__rsub__",
+	scanner.STAR:          "__rmul__",
+	scanner.SLASH:         "__rdiv__",
+	scanner.MODULO:        "__rmod__",
+	scanner.LESS:          "__gt__",
+	scanner.LESS_EQUAL:    "__ge__",
+	scanner.GREATER:       "__lt__",
+	scanner.GREATER_EQUAL: "__le__",
+	scanner.EQUAL_EQUAL:   "__eq__",
+	scanner.BANG_EQUAL:    "__eq__",
+}
+
+// specialMethod returns the special method name bound to value, if value is an instance whose
+// class or traits define it.
+func (i *Interpreter) specialMethod(value any, name string) (*loxFunction, bool) {
+	instance, ok := value.(*loxInstance)
+	if !ok {
+		return nil, false
+	}
+
+	method, ok := instance.class.findMethod(name)
+	if !ok || method.isClassGetter {
+		return nil, false
+	}
+
+	return method.bind(instance), true
+}
+
+func (i *Interpreter) callSpecialMethod(method *loxFunction, token scanner.Token, arguments ...any) (any, error) {
+	minArity, maxArity := method.arity()
+	count := int32(len(arguments))
+
+	if count < minArity || (maxArity != variadic && count > maxArity) {
+		return nil, i.newError(token, fmt.Sprintf("Special method '%s' should accept %d arguments.", method.funStmt.Name.Lexeme, count))
+	}
+
+	return method.call(i, arguments, token)
+}
+
+// overloadedBinary dispatches a binary operator to the special methods of instance operands. The
+// second result is false when neither operand overloads the operator.
+func (i *Interpreter) overloadedBinary(token scanner.Token, left any, right any) (any, bool, error) {
+	var result any
+	var err error
+
+	if method, ok := i.specialMethod(left, operatorMethods[token.Type]); ok {
+		result, err = i.callSpecialMethod(method, token, right)
+	} else if method, ok := i.specialMethod(right, reflectedOperatorMethods[token.Type]); ok {
+		result, err = i.callSpecialMethod(method, token, left)
+	} else {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, true, err
+	}
+
+	if token.Type == scanner.BANG_EQUAL {
+		return !i.isTruthy(result), true, nil
+	}
+
+	return result, true, nil
+}
+
+// equal compares two values like "==" does, calling '__eq__' when an operand defines it.
+func (i *Interpreter) equal(token scanner.Token, left any, right any) (bool, error) {
+	result, overloaded, err := i.overloadedBinary(token, left, right)
+	if err != nil {
+		return false, err
+	}
+
+	if overloaded {
+		return i.isTruthy(result), nil
+	}
+
+	return i.areEqual(left, right), nil
+}
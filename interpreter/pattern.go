@@ -0,0 +1,326 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/parser"
+	"glox/scanner"
+)
+
+// matchPattern reports whether value matches the pattern, defining the names bound by the
+// pattern in env.
+func (i *Interpreter) matchPattern(pattern parser.Pattern, value any, env *environment) (bool, error) {
+	switch pattern := pattern.(type) {
+	case parser.LiteralPattern:
+		literal, _ := i.VisitLiteralExpr(parser.LiteralExpr{Value: pattern.Value})
+		return i.areEqual(value, literal), nil
+	case parser.RangePattern:
+		return i.matchRange(pattern, value)
+	case parser.BindingPattern:
+		if !pattern.IsWildcard() {
+			env.define(pattern.Name.Lexeme, value)
+		}
+		return true, nil
+	case parser.ValuePattern:
+		expected, err := i.Evaluate(pattern.Value)
+		if err != nil {
+			return false, err
+		}
+		return i.areEqual(value, expected), nil
+	case parser.ArrayPattern:
+		return i.matchArray(pattern, value, env)
+	case parser.ClassPattern:
+		return i.matchClass(pattern, value, env)
+	case parser.ObjectPattern:
+		return i.matchObject(pattern, value, env)
+	}
+
+	panic("Unknown pattern type.")
+}
+
+func (i *Interpreter) matchRange(pattern parser.RangePattern, value any) (bool, error) {
+	if !isNumeric(value) {
+		return false, nil
+	}
+
+	start, _ := i.VisitLiteralExpr(parser.LiteralExpr{Value: pattern.Start})
+	end, _ := i.VisitLiteralExpr(parser.LiteralExpr{Value: pattern.End})
+
+	if !isNumeric(start) || !isNumeric(end) {
+		return false, i.newError(pattern.Token, "Range pattern bounds should be numbers.")
+	}
+
+	fromStart, err := i.compareNumbers(value, start, pattern.Token)
+	if err != nil {
+		return false, err
+	}
+
+	toEnd, err := i.compareNumbers(value, end, pattern.Token)
+	if err != nil {
+		return false, err
+	}
+
+	if pattern.Inclusive {
+		return fromStart >= 0 && toEnd <= 0, nil
+	}
+
+	return fromStart >= 0 && toEnd < 0, nil
+}
+
+func (i *Interpreter) compareNumbers(obj1 any, obj2 any, token scanner.Token) (int, error) {
+	if i.areBigNumberOperands(obj1, obj2) {
+		return compareBigNumbers(obj1, obj2, token)
+	}
+
+	left, right := obj1.(float64), obj2.(float64)
+	switch {
+	case left < right:
+		return -1, nil
+	case left > right:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func (i *Interpreter) matchArray(pattern parser.ArrayPattern, value any, env *environment) (bool, error) {
+	array, ok := value.(*loxArray)
+	if !ok {
+		return false, nil
+	}
+
+	if len(array.elements) < len(pattern.Elements) || (pattern.Rest == nil && len(array.elements) != len(pattern.Elements)) {
+		return false, nil
+	}
+
+	for idx, element := range pattern.Elements {
+		if ok, err := i.matchPattern(element, array.elements[idx], env); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	if pattern.Rest != nil {
+		env.define(pattern.Rest.Lexeme, i.restElements(array, len(pattern.Elements)))
+	}
+
+	return true, nil
+}
+
+func (i *Interpreter) matchClass(pattern parser.ClassPattern, value any, env *environment) (bool, error) {
+	callee, err := i.Evaluate(pattern.Class)
+	if err != nil {
+		return false, err
+	}
+
+	if variant, ok := callee.(*loxEnumVariant); ok {
+		return i.matchVariant(pattern, variant, value, env)
+	}
+
+	class, ok := callee.(*loxClass)
+	if !ok {
+		return false, i.newError(pattern.Parenthesis, "Class patterns can only match classes and enum variants.")
+	}
+
+	instance, ok := value.(*loxInstance)
+	if !ok || !instance.class.inherits(class) {
+		return false, nil
+	}
+
+	var parameters []parser.Parameter
+	if initializer, ok := class.findMethod("init"); ok {
+		parameters = initializer.funStmt.Parameters
+	}
+
+	if len(pattern.Arguments) > len(parameters) {
+		return false, i.newError(pattern.Parenthesis, fmt.Sprintf("Class pattern expects at most %d fields of '%s', but got %d.", len(parameters), class.metaClass.stmt.Name.Lexeme, len(pattern.Arguments)))
+	}
+
+	for idx, argument := range pattern.Arguments {
+		field, ok := instance.fields[parameters[idx].Name.Lexeme]
+		if !ok {
+			return false, nil
+		}
+
+		if ok, err := i.matchPattern(argument, field, env); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchVariant matches the values carried by an enum value against the arguments of a pattern like
+// "Result.Ok(value)".
+func (i *Interpreter) matchVariant(pattern parser.ClassPattern, variant *loxEnumVariant, value any, env *environment) (bool, error) {
+	if len(pattern.Arguments) > len(variant.stmt.Parameters) {
+		return false, i.newError(pattern.Parenthesis, fmt.Sprintf("Variant pattern expects at most %d values of '%s.%s', but got %d.", len(variant.stmt.Parameters), variant.enum.Name().Lexeme, variant.name(), len(pattern.Arguments)))
+	}
+
+	enumValue, ok := value.(*loxEnumValue)
+	if !ok || enumValue.variant != variant {
+		return false, nil
+	}
+
+	for idx, argument := range pattern.Arguments {
+		if ok, err := i.matchPattern(argument, enumValue.values[idx], env); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (i *Interpreter) matchObject(pattern parser.ObjectPattern, value any, env *environment) (bool, error) {
+	instance, ok := value.(*loxInstance)
+	if !ok {
+		return false, nil
+	}
+
+	for _, field := range pattern.Fields {
+		if _, err := instance.get(field.Name); err != nil {
+			return false, nil
+		}
+
+		property, err := i.getProperty(instance, field.Name)
+		if err != nil {
+			return false, err
+		}
+
+		if ok, err := i.matchPattern(field.Pattern, property, env); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// destructure binds the names of a declaration pattern to the parts of value, failing with a
+// runtime error when value doesn't have the shape of the pattern.
+func (i *Interpreter) destructure(pattern parser.Pattern, value any, env *environment) error {
+	switch pattern := pattern.(type) {
+	case parser.BindingPattern:
+		if !pattern.IsWildcard() {
+			return i.define(env, pattern.Name, value)
+		}
+	case parser.ArrayPattern:
+		array, err := i.destructuredArray(value, len(pattern.Elements), pattern.Rest != nil, pattern.Bracket)
+		if err != nil {
+			return err
+		}
+
+		for idx, element := range pattern.Elements {
+			if err := i.destructure(element, array.elements[idx], env); err != nil {
+				return err
+			}
+		}
+
+		if pattern.Rest != nil {
+			return i.define(env, *pattern.Rest, i.restElements(array, len(pattern.Elements)))
+		}
+	case parser.ObjectPattern:
+		if _, ok := value.(*loxInstance); !ok {
+			text, err := i.Stringify(value)
+			if err != nil {
+				return err
+			}
+			return i.newError(pattern.Brace, fmt.Sprintf("Only instances can be destructured with '{}', got '%s'.", text))
+		}
+
+		for _, field := range pattern.Fields {
+			property, err := i.getProperty(value, field.Name)
+			if err != nil {
+				return err
+			}
+
+			if err := i.destructure(field.Pattern, property, env); err != nil {
+				return err
+			}
+		}
+	default:
+		panic("Invalid pattern in declaration.")
+	}
+
+	return nil
+}
+
+func (i *Interpreter) destructuredArray(value any, count int, hasRest bool, bracket scanner.Token) (*loxArray, error) {
+	array, ok, err := i.asArray(value, bracket)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		text, err := i.Stringify(value)
+		if err != nil {
+			return nil, err
+		}
+		return nil, i.newError(bracket, fmt.Sprintf("Only arrays can be destructured with '[]', got '%s'.", text))
+	}
+
+	if hasRest && len(array.elements) < count {
+		return nil, i.newError(bracket, fmt.Sprintf("Expected at least %d elements to destructure, but got %d.", count, len(array.elements)))
+	}
+
+	if !hasRest && len(array.elements) != count {
+		return nil, i.newError(bracket, fmt.Sprintf("Expected %d elements to destructure, but got %d.", count, len(array.elements)))
+	}
+
+	return array, nil
+}
+
+func (i *Interpreter) restElements(array *loxArray, from int) *loxArray {
+	rest := make([]any, len(array.elements)-from)
+	copy(rest, array.elements[from:])
+
+	return newLoxArray(rest)
+}
+
+// assignTargets assigns the elements of value to existing variables, properties and array slots.
+func (i *Interpreter) assignTargets(targets []parser.Expr, rest parser.Expr, value any, bracket scanner.Token) error {
+	array, err := i.destructuredArray(value, len(targets), rest != nil, bracket)
+	if err != nil {
+		return err
+	}
+
+	for idx, target := range targets {
+		if err := i.assignTarget(target, array.elements[idx]); err != nil {
+			return err
+		}
+	}
+
+	if rest != nil {
+		return i.assignTarget(rest, i.restElements(array, len(targets)))
+	}
+
+	return nil
+}
+
+func (i *Interpreter) assignTarget(target parser.Expr, value any) error {
+	switch target := target.(type) {
+	case parser.VariableExpr:
+		return i.assignVariable(target, target.Name, value)
+	case parser.GetExpr:
+		if target.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			return i.setPrivate(target.Object, target.Name, value)
+		}
+
+		object, err := i.Evaluate(target.Object)
+		if err != nil {
+			return err
+		}
+		return i.setProperty(object, target.Name, value)
+	case parser.ArrayGetExpr:
+		index, err := i.Evaluate(target.Index)
+		if err != nil {
+			return err
+		}
+		array, err := i.Evaluate(target.Array)
+		if err != nil {
+			return err
+		}
+		return i.setIndex(array, index, target.Bracket, value)
+	case parser.ArrayExpr:
+		targets, rest := parser.SplitRest(target.Elements)
+		return i.assignTargets(targets, rest, value, target.Bracket)
+	}
+
+	panic("Invalid assignment target.")
+}
@@ -0,0 +1,200 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/parser"
+	"glox/scanner"
+	"math"
+)
+
+// loxRange is a lazy sequence of numbers from start to end, which is left out unless the range is
+// inclusive. Its elements are only computed when it's iterated, indexed or turned into an array.
+type loxRange struct {
+	start     float64
+	end       float64
+	step      float64
+	inclusive bool
+}
+
+// maxRangeLength is the most elements a range can have. Up to it, every index is an integer that a
+// number represents exactly, so measuring and indexing the range stay correct.
+const maxRangeLength = 1 << 53
+
+// maxArrayLength is the most elements a range can have to be turned into an array.
+const maxArrayLength = 1 << 24
+
+// size returns the number of elements of the range. Unlike length, it can't overflow.
+func (r *loxRange) size() float64 {
+	span := (r.end - r.start) / r.step
+	if span < 0 {
+		return 0
+	}
+
+	if r.inclusive {
+		return math.Floor(span) + 1
+	}
+
+	return math.Ceil(span)
+}
+
+// length returns the number of elements of the range, which VisitRangeExpr keeps within
+// maxRangeLength.
+func (r *loxRange) length() int {
+	return int(r.size())
+}
+
+func (r *loxRange) at(index int) float64 {
+	return r.start + float64(index)*r.step
+}
+
+func (r *loxRange) equals(other *loxRange) bool {
+	return *r == *other
+}
+
+func (r *loxRange) toArray() *loxArray {
+	elements := make([]any, 0, r.length())
+	for idx := 0; idx < r.length(); idx++ {
+		elements = append(elements, r.at(idx))
+	}
+
+	return newLoxArray(elements)
+}
+
+func (r *loxRange) String() string {
+	operator := ".."
+	if !r.inclusive {
+		operator = "..<"
+	}
+
+	result := fmt.Sprintf("%v%s%v", r.start, operator, r.end)
+	if r.step != 1 {
+		result += fmt.Sprintf(" step %v", r.step)
+	}
+
+	return result
+}
+
+func (i *Interpreter) VisitRangeExpr(expr parser.RangeExpr) (any, error) {
+	bounds := make([]float64, 0, 3)
+
+	for _, operand := range []parser.Expr{expr.Start, expr.End, expr.Step} {
+		if operand == nil {
+			bounds = append(bounds, 1)
+			continue
+		}
+
+		value, err := i.Evaluate(operand)
+		if err != nil {
+			return nil, err
+		}
+
+		number, ok := value.(float64)
+		if !ok {
+			return nil, i.newError(expr.Operator, "Range bounds and step should be numbers.")
+		}
+		if math.IsInf(number, 0) || math.IsNaN(number) {
+			return nil, i.newError(expr.Operator, "Range bounds and step should be finite.")
+		}
+		bounds = append(bounds, number)
+	}
+
+	if bounds[2] == 0 {
+		return nil, i.newError(expr.Operator, "Range step can't be zero.")
+	}
+
+	result := &loxRange{start: bounds[0], end: bounds[1], step: bounds[2], inclusive: expr.Operator.Type == scanner.DOT_DOT}
+	if result.size() > maxRangeLength {
+		return nil, i.newError(expr.Operator, fmt.Sprintf("Ranges can have at most %d elements.", maxRangeLength))
+	}
+
+	return result, nil
+}
+
+// asArray returns arrays as they are and materializes ranges and enums, for the places that need
+// an array.
+// It reports whether value can be turned into an array, and fails for ranges that are too large
+// for it.
+func (i *Interpreter) asArray(value any, token scanner.Token) (*loxArray, bool, error) {
+	switch value := value.(type) {
+	case *loxArray:
+		return value, true, nil
+	case *loxRange:
+		if value.length() > maxArrayLength {
+			return nil, true, i.newError(token, fmt.Sprintf("Only ranges of at most %d elements can be turned into arrays.", maxArrayLength))
+		}
+		return value.toArray(), true, nil
+	case *loxEnum:
+		return newLoxArray(value.values()), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// iterate calls visit with every element of an array, a range or an enum, without materializing
+// ranges.
+func (i *Interpreter) iterate(value any, token scanner.Token, visit func(element any) error) error {
+	switch value := value.(type) {
+	case *loxArray:
+		for _, element := range value.elements {
+			if err := visit(element); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *loxRange:
+		for idx := 0; idx < value.length(); idx++ {
+			if err := visit(value.at(idx)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *loxEnum:
+		for _, member := range value.values() {
+			if err := visit(member); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return i.newError(token, "Only arrays, ranges and enums can be iterated.")
+}
+
+func (i *Interpreter) VisitComprehensionExpr(expr parser.ComprehensionExpr) (any, error) {
+	iterable, err := i.Evaluate(expr.Iterable)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	elements := make([]any, 0)
+	err = i.iterate(iterable, expr.Name, func(value any) error {
+		// Each element gets its own environment, so closures capture the value they were created with.
+		i.environment = newEnvironment(previous)
+		i.environment.define(expr.Name.Lexeme, value)
+
+		if expr.Condition != nil {
+			condition, err := i.Evaluate(expr.Condition)
+			if err != nil || !i.isTruthy(condition) {
+				return err
+			}
+		}
+
+		element, err := i.Evaluate(expr.Element)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newLoxArray(elements), nil
+}
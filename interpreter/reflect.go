@@ -0,0 +1,160 @@
+package interpreter
+
+import (
+	"fmt"
+	"glox/scanner"
+	"sort"
+)
+
+type nativeType struct {
+}
+
+func (n *nativeType) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeType) call(_ *Interpreter, arguments []any, _ scanner.Token) (any, error) {
+	switch value := arguments[0].(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return "boolean", nil
+	case float64:
+		return "number", nil
+	case string:
+		return "string", nil
+	case *loxBigInt:
+		return "bigint", nil
+	case *loxDecimal:
+		return "decimal", nil
+	case *loxArray:
+		return "array", nil
+	case *loxRange:
+		return "range", nil
+	case *loxInstance:
+		return value.class.metaClass.stmt.Name.Lexeme, nil
+	case *loxEnumValue:
+		return value.variant.enum.Name().Lexeme, nil
+	case *loxEnum:
+		return "enum", nil
+	case *loxClass:
+		return "class", nil
+	case *loxTrait:
+		return "trait", nil
+	case callable:
+		return "function", nil
+	}
+
+	return "unknown", nil
+}
+
+func (n *nativeType) String() string {
+	return "<native fn>"
+}
+
+type nativeFields struct {
+}
+
+func (n *nativeFields) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeFields) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	instance, ok := arguments[0].(*loxInstance)
+	if !ok {
+		return nil, &Error{Token: token, Message: "Argument to 'fields' should be an instance."}
+	}
+
+	names := make([]string, 0, len(instance.fields))
+	for name := range instance.fields {
+		names = append(names, name)
+	}
+
+	return sortedNames(names), nil
+}
+
+func (n *nativeFields) String() string {
+	return "<native fn>"
+}
+
+// nativeClassReflection is a native that describes a class, like 'methods' or 'superclass'.
+type nativeClassReflection struct {
+	name    string
+	reflect func(class *loxClass) any
+}
+
+func (n *nativeClassReflection) arity() (int32, int32) {
+	return 1, 1
+}
+
+func (n *nativeClassReflection) call(_ *Interpreter, arguments []any, token scanner.Token) (any, error) {
+	class, ok := arguments[0].(*loxClass)
+	if !ok {
+		return nil, &Error{Token: token, Message: fmt.Sprintf("Argument to '%s' should be a class.", n.name)}
+	}
+
+	return n.reflect(class), nil
+}
+
+func (n *nativeClassReflection) String() string {
+	return "<native fn>"
+}
+
+func newClassReflections() []*nativeClassReflection {
+	return []*nativeClassReflection{
+		{name: "methods", reflect: func(class *loxClass) any {
+			return class.memberNames(func(metaClass *loxMetaClass) map[string]*loxFunction { return metaClass.methods }, false)
+		}},
+		{name: "getters", reflect: func(class *loxClass) any {
+			return class.memberNames(func(metaClass *loxMetaClass) map[string]*loxFunction { return metaClass.methods }, true)
+		}},
+		{name: "staticMethods", reflect: func(class *loxClass) any {
+			return class.memberNames(func(metaClass *loxMetaClass) map[string]*loxFunction { return metaClass.staticMethods }, false)
+		}},
+		{name: "superclass", reflect: func(class *loxClass) any {
+			if class.superclass == nil {
+				return nil
+			}
+			return class.superclass
+		}},
+		{name: "traits", reflect: func(class *loxClass) any {
+			traits := make([]any, 0, len(class.metaClass.traits))
+			for _, trait := range class.metaClass.traits {
+				traits = append(traits, trait)
+			}
+			return newLoxArray(traits)
+		}},
+	}
+}
+
+// memberNames lists the public methods, or the getters, that the class declares or inherits.
+func (c *loxClass) memberNames(members func(*loxMetaClass) map[string]*loxFunction, getters bool) *loxArray {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+
+	for current := c; current != nil; current = current.superclass {
+		for name, method := range members(current.metaClass) {
+			if seen[name] || method.funStmt.Name.Type == scanner.PRIVATE_IDENTIFIER {
+				continue
+			}
+			seen[name] = true
+
+			if (method.funStmt.Parameters == nil) == getters {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return sortedNames(names)
+}
+
+func sortedNames(names []string) *loxArray {
+	sort.Strings(names)
+
+	elements := make([]any, 0, len(names))
+	for _, name := range names {
+		elements = append(elements, name)
+	}
+
+	return newLoxArray(elements)
+}
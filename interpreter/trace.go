@@ -0,0 +1,23 @@
+package interpreter
+
+import "glox/parser"
+
+// Tracer observes a program as it runs, to measure its coverage or to profile it.
+type Tracer interface {
+	// Statement is called before stmt runs.
+	Statement(stmt parser.Stmt)
+	// Branch is called when an IfStmt, a TernaryExpr or a LogicalExpr picks one of its two arms.
+	// first is true for the then branch of an if, the left value of a ternary, and a logical
+	// expression that short-circuits on its left operand.
+	Branch(node any, first bool)
+	// Call is called before a Glox function or method runs, with its name and the line it's
+	// declared on.
+	Call(name string, line int32)
+	// Return is called when the function of the latest Call returns, whether it fails or not.
+	Return()
+}
+
+// SetTracer makes the interpreter report what it runs to tracer. A nil tracer stops the tracing.
+func (i *Interpreter) SetTracer(tracer Tracer) {
+	i.tracer = tracer
+}
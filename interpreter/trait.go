@@ -4,28 +4,239 @@ import (
 	"fmt"
 	"glox/parser"
 	"glox/scanner"
+	"slices"
+	"sort"
 )
 
 type loxTrait struct {
 	stmt parser.TraitStmt
+	// members are the members of the trait after composing the traits it uses.
+	members *traitMembers
+	closure *environment
 }
 
-func newTrait(stmt parser.TraitStmt) *loxTrait {
-	return &loxTrait{stmt: stmt}
+func newTrait(stmt parser.TraitStmt, members *traitMembers, closure *environment) *loxTrait {
+	return &loxTrait{stmt: stmt, members: members, closure: closure}
+}
+
+// method creates an instance method or setter declared by the trait. Once bound, it knows its
+// trait, so 'super' can continue the lookup after it.
+func (t *loxTrait) method(stmt parser.FunctionStmt) *loxFunction {
+	method := newLoxMethod(stmt, t.closure)
+	method.trait = t
+	return method
 }
 
 func (t *loxTrait) Name() scanner.Token {
 	return t.stmt.Name
 }
 
-func (t *loxTrait) Methods() []parser.FunctionStmt {
-	return t.stmt.Methods
+// adapt returns the members of the trait as they are used by a class or another trait, with the
+// aliases of use added and its excluded members left out.
+func (t *loxTrait) adapt(use parser.TraitUse) (*traitMembers, error) {
+	excluded := make(map[string]bool)
+	for _, member := range use.Excluded {
+		if !t.members.provides(member.Lexeme) {
+			return nil, t.unknownMember(member)
+		}
+		excluded[member.Lexeme] = true
+	}
+
+	for _, alias := range use.Aliases {
+		if !t.members.provides(alias.Member.Lexeme) {
+			return nil, t.unknownMember(alias.Member)
+		}
+		if t.members.provides(alias.Alias.Lexeme) && !excluded[alias.Alias.Lexeme] {
+			return nil, &Error{Token: alias.Alias, Message: fmt.Sprintf("Trait '%s' already has a member '%s'.", t.Name().Lexeme, alias.Alias.Lexeme)}
+		}
+	}
+
+	adapted := newTraitMembers()
+	adapted.fields = t.members.fields
+	adapted.staticFields = t.members.staticFields
+	for name, member := range t.members.required {
+		adapted.required[name] = member
+	}
+
+	sets, adaptedSets := t.members.sets(), adapted.sets()
+	for i, set := range sets {
+		for name, member := range set.members {
+			if !excluded[name] {
+				member.via = use.Trait.Name
+				adaptedSets[i].add(name, member)
+			}
+		}
+
+		for _, alias := range use.Aliases {
+			if member, ok := set.members[alias.Member.Lexeme]; ok {
+				member.stmt.Name = alias.Alias
+				member.via = use.Trait.Name
+				adaptedSets[i].add(alias.Alias.Lexeme, member)
+			}
+		}
+	}
+
+	return adapted, nil
+}
+
+func (t *loxTrait) unknownMember(member scanner.Token) error {
+	return &Error{Token: member, Message: fmt.Sprintf("Trait '%s' has no member '%s'.", t.Name().Lexeme, member.Lexeme)}
 }
 
-func (t *loxTrait) StaticMethods() []parser.FunctionStmt {
-	return t.stmt.StaticMethods
+// implements reports whether the trait is trait or uses it, directly or through other traits.
+func (t *loxTrait) implements(trait *loxTrait) bool {
+	if t == trait {
+		return true
+	}
+
+	for _, used := range t.members.traits {
+		if used.implements(trait) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (t *loxTrait) String() string {
 	return fmt.Sprintf("<trait %s>", t.Name().Lexeme)
 }
+
+// traitMember is a method or setter taken from a trait. origin and name identify where it was
+// declared, so the same member reaching a class through two traits isn't reported as a conflict.
+type traitMember struct {
+	stmt   parser.FunctionStmt
+	origin *loxTrait
+	name   string
+	// via is the trait listed in the declaration that brought the member in.
+	via scanner.Token
+}
+
+// memberSet holds one kind of trait member by name, along with the names two traits disagree on.
+type memberSet struct {
+	kind      string
+	members   map[string]traitMember
+	conflicts map[string]traitMember
+}
+
+func newMemberSet(kind string) *memberSet {
+	return &memberSet{kind: kind, members: make(map[string]traitMember), conflicts: make(map[string]traitMember)}
+}
+
+func (s *memberSet) add(name string, member traitMember) {
+	if existing, ok := s.members[name]; ok && (existing.origin != member.origin || existing.name != member.name) {
+		s.conflicts[name] = member
+		return
+	}
+
+	s.members[name] = member
+}
+
+// override replaces a trait member with one declared by the class or trait itself, which
+// resolves any conflict on its name.
+func (s *memberSet) override(name string, member traitMember) {
+	delete(s.conflicts, name)
+	s.members[name] = member
+}
+
+type traitMembers struct {
+	methods       *memberSet
+	staticMethods *memberSet
+	setters       *memberSet
+	staticSetters *memberSet
+	fields        []parser.VarStmt
+	staticFields  []parser.VarStmt
+	// required are the methods the host class still has to implement.
+	required map[string]traitMember
+	// traits are the traits that were composed, in the order they are listed.
+	traits []*loxTrait
+}
+
+func newTraitMembers() *traitMembers {
+	return &traitMembers{
+		methods:       newMemberSet("Method"),
+		staticMethods: newMemberSet("Static method"),
+		setters:       newMemberSet("Setter"),
+		staticSetters: newMemberSet("Static setter"),
+		fields:        make([]parser.VarStmt, 0),
+		staticFields:  make([]parser.VarStmt, 0),
+		required:      make(map[string]traitMember),
+		traits:        make([]*loxTrait, 0),
+	}
+}
+
+func (t *traitMembers) sets() []*memberSet {
+	return []*memberSet{t.methods, t.staticMethods, t.setters, t.staticSetters}
+}
+
+func (t *traitMembers) provides(name string) bool {
+	for _, set := range t.sets() {
+		if _, ok := set.members[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *traitMembers) include(other *traitMembers) {
+	sets, otherSets := t.sets(), other.sets()
+	for i, set := range otherSets {
+		for name, member := range set.members {
+			sets[i].add(name, member)
+		}
+	}
+
+	t.fields = slices.Concat(t.fields, other.fields)
+	t.staticFields = slices.Concat(t.staticFields, other.staticFields)
+
+	for name, member := range other.required {
+		t.required[name] = member
+	}
+}
+
+// resolveConflicts drops the conflicts on members a class declares itself.
+func (t *traitMembers) resolveConflicts(declared ...[]parser.FunctionStmt) {
+	for i, set := range t.sets() {
+		for _, member := range declared[i] {
+			delete(set.conflicts, member.Name.Lexeme)
+		}
+	}
+}
+
+// checkConflicts reports a member that two traits define differently and that the declaration
+// named by token doesn't resolve by declaring the member itself or excluding it from a trait.
+func (t *traitMembers) checkConflicts(token scanner.Token) error {
+	for _, set := range t.sets() {
+		names := make([]string, 0, len(set.conflicts))
+		for name := range set.conflicts {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+
+		first, second := set.members[names[0]], set.conflicts[names[0]]
+		return &Error{Token: token, Message: fmt.Sprintf("%s '%s' is defined by both trait '%s' and trait '%s'.", set.kind, names[0], first.via.Lexeme, second.via.Lexeme)}
+	}
+
+	return nil
+}
+
+// unimplemented returns the required methods that neither the traits nor implemented provide,
+// sorted by name.
+func (t *traitMembers) unimplemented(implemented func(name string) bool) []traitMember {
+	missing := make([]traitMember, 0)
+	for name, member := range t.required {
+		if _, ok := t.methods.members[name]; !ok && !implemented(name) {
+			missing = append(missing, member)
+		}
+	}
+
+	sort.Slice(missing, func(a, b int) bool {
+		return missing[a].name < missing[b].name
+	})
+
+	return missing
+}
@@ -12,20 +12,35 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("[line %d] Error%s: %s\n", e.Line, e.Where, e.Message)
 }
 
+// BreakInterrupt stops the innermost loop or switch, or the loop named by Label when it is set.
 type BreakInterrupt struct {
+	Label string
 }
 
 func (e *BreakInterrupt) Error() string {
 	return "Break interrupt"
 }
 
+// Targets reports whether the interrupt stops the loop labeled label.
+func (e *BreakInterrupt) Targets(label string) bool {
+	return e.Label == "" || e.Label == label
+}
+
+// ContinueInterrupt skips to the next iteration of the innermost loop, or of the loop named by
+// Label when it is set.
 type ContinueInterrupt struct {
+	Label string
 }
 
 func (e *ContinueInterrupt) Error() string {
 	return "Continue interrupt"
 }
 
+// Targets reports whether the interrupt continues the loop labeled label.
+func (e *ContinueInterrupt) Targets(label string) bool {
+	return e.Label == "" || e.Label == label
+}
+
 type ReturnInterrupt struct {
 	Value any
 }
@@ -16,10 +16,16 @@ type VisitorExpr interface {
 	VisitUnaryExpr(UnaryExpr) (any, error)
 	VisitGetExpr(GetExpr) (any, error)
 	VisitArrayGetExpr(ArrayGetExpr) (any, error)
+	VisitOptionalChainExpr(OptionalChainExpr) (any, error)
 	VisitCallExpr(CallExpr) (any, error)
 	VisitLambdaExpr(LambdaExpr) (any, error)
 	VisitThisExpr(ThisExpr) (any, error)
 	VisitVariableExpr(VariableExpr) (any, error)
+	VisitMatchExpr(MatchExpr) (any, error)
+	VisitSpreadExpr(SpreadExpr) (any, error)
+	VisitRangeExpr(RangeExpr) (any, error)
+	VisitComprehensionExpr(ComprehensionExpr) (any, error)
+	VisitDestructureAssignmentExpr(DestructureAssignmentExpr) (any, error)
 }
 
 type Expr interface {
@@ -37,6 +43,7 @@ func (a ArrayExpr) Accept(visitor VisitorExpr) (any, error) {
 
 type TernaryExpr struct {
 	Condition Expr
+	Question  scanner.Token
 	Left      Expr
 	Right     Expr
 }
@@ -130,8 +137,9 @@ func (u UnaryExpr) Accept(visitor VisitorExpr) (any, error) {
 }
 
 type GetExpr struct {
-	Object Expr
-	Name   scanner.Token
+	Object   Expr
+	Name     scanner.Token
+	Optional bool
 }
 
 func (g GetExpr) Accept(visitor VisitorExpr) (any, error) {
@@ -139,19 +147,30 @@ func (g GetExpr) Accept(visitor VisitorExpr) (any, error) {
 }
 
 type ArrayGetExpr struct {
-	Array   Expr
-	Bracket scanner.Token
-	Index   Expr
+	Array    Expr
+	Bracket  scanner.Token
+	Index    Expr
+	Optional bool
 }
 
 func (a ArrayGetExpr) Accept(visitor VisitorExpr) (any, error) {
 	return visitor.VisitArrayGetExpr(a)
 }
 
+type OptionalChainExpr struct {
+	Expr Expr
+}
+
+func (o OptionalChainExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitOptionalChainExpr(o)
+}
+
 type CallExpr struct {
-	Callee      Expr
-	Parenthesis scanner.Token
-	Arguments   []Expr
+	Callee         Expr
+	Parenthesis    scanner.Token
+	Arguments      []Expr
+	NamedArguments []NamedArgument
+	Optional       bool
 }
 
 func (c CallExpr) Accept(visitor VisitorExpr) (any, error) {
@@ -160,7 +179,8 @@ func (c CallExpr) Accept(visitor VisitorExpr) (any, error) {
 
 type LambdaExpr struct {
 	Parenthesis scanner.Token
-	Parameters  []scanner.Token
+	Parameters  []Parameter
+	ReturnType  *TypeAnnotation
 	Body        []Stmt
 }
 
@@ -183,3 +203,56 @@ type VariableExpr struct {
 func (v VariableExpr) Accept(visitor VisitorExpr) (any, error) {
 	return visitor.VisitVariableExpr(v)
 }
+
+type MatchExpr struct {
+	Keyword scanner.Token
+	Subject Expr
+	Cases   []MatchCase
+}
+
+func (m MatchExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitMatchExpr(m)
+}
+
+type SpreadExpr struct {
+	Ellipsis scanner.Token
+	Expr     Expr
+}
+
+func (s SpreadExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitSpreadExpr(s)
+}
+
+type RangeExpr struct {
+	Start    Expr
+	Operator scanner.Token
+	End      Expr
+	Step     Expr
+}
+
+func (r RangeExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitRangeExpr(r)
+}
+
+type ComprehensionExpr struct {
+	Bracket   scanner.Token
+	Element   Expr
+	Name      scanner.Token
+	Iterable  Expr
+	Condition Expr
+}
+
+func (c ComprehensionExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitComprehensionExpr(c)
+}
+
+type DestructureAssignmentExpr struct {
+	Bracket scanner.Token
+	Targets []Expr
+	Rest    Expr
+	Value   Expr
+}
+
+func (d DestructureAssignmentExpr) Accept(visitor VisitorExpr) (any, error) {
+	return visitor.VisitDestructureAssignmentExpr(d)
+}
@@ -0,0 +1,108 @@
+package parser
+
+// StmtLine returns the line a statement starts on, or 0 for blocks, which only group other
+// statements.
+func StmtLine(stmt Stmt) int32 {
+	switch stmt := stmt.(type) {
+	case ExpressionStmt:
+		return ExprLine(stmt.Expression)
+	case PrintStmt:
+		return stmt.Keyword.Line
+	case VarStmt:
+		return stmt.Name.Line
+	case DestructureStmt:
+		return stmt.Keyword.Line
+	case ClassStmt:
+		return stmt.Name.Line
+	case TraitStmt:
+		return stmt.Name.Line
+	case EnumStmt:
+		return stmt.Name.Line
+	case FunctionStmt:
+		return stmt.Name.Line
+	case IfStmt:
+		return stmt.Keyword.Line
+	case WhileStmt:
+		return stmt.Keyword.Line
+	case DoWhileStmt:
+		return stmt.Keyword.Line
+	case ForStmt:
+		return stmt.Keyword.Line
+	case SwitchStmt:
+		return stmt.Keyword.Line
+	case BreakStmt:
+		return stmt.Keyword.Line
+	case ContinueStmt:
+		return stmt.Keyword.Line
+	case ReturnStmt:
+		return stmt.Keyword.Line
+	case DeferStmt:
+		return stmt.Keyword.Line
+	case UsingStmt:
+		return stmt.Keyword.Line
+	case AssertStmt:
+		return stmt.Keyword.Line
+	}
+
+	return 0
+}
+
+// ExprLine returns the line of the leftmost token of an expression, or 0 for a lone literal.
+func ExprLine(expr Expr) int32 {
+	switch expr := expr.(type) {
+	case ArrayExpr:
+		return expr.Bracket.Line
+	case TernaryExpr:
+		return firstLine(ExprLine(expr.Condition), expr.Question.Line)
+	case AssignmentExpr:
+		return expr.Name.Line
+	case LogicalExpr:
+		return firstLine(ExprLine(expr.Left), expr.Operator.Line)
+	case SetExpr:
+		return firstLine(ExprLine(expr.Object), expr.Name.Line)
+	case ArraySetExpr:
+		return firstLine(ExprLine(expr.Array), expr.Bracket.Line)
+	case SuperExpr:
+		return expr.Keyword.Line
+	case BinaryExpr:
+		return firstLine(ExprLine(expr.Left), expr.Operator.Line)
+	case GroupingExpr:
+		return ExprLine(expr.Expr)
+	case UnaryExpr:
+		return expr.Operator.Line
+	case GetExpr:
+		return firstLine(ExprLine(expr.Object), expr.Name.Line)
+	case ArrayGetExpr:
+		return firstLine(ExprLine(expr.Array), expr.Bracket.Line)
+	case OptionalChainExpr:
+		return ExprLine(expr.Expr)
+	case CallExpr:
+		return firstLine(ExprLine(expr.Callee), expr.Parenthesis.Line)
+	case LambdaExpr:
+		return expr.Parenthesis.Line
+	case ThisExpr:
+		return expr.Keyword.Line
+	case VariableExpr:
+		return expr.Name.Line
+	case MatchExpr:
+		return expr.Keyword.Line
+	case SpreadExpr:
+		return expr.Ellipsis.Line
+	case RangeExpr:
+		return firstLine(ExprLine(expr.Start), expr.Operator.Line)
+	case ComprehensionExpr:
+		return expr.Bracket.Line
+	case DestructureAssignmentExpr:
+		return expr.Bracket.Line
+	}
+
+	return 0
+}
+
+func firstLine(line int32, fallback int32) int32 {
+	if line > 0 {
+		return line
+	}
+
+	return fallback
+}
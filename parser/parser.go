@@ -3,11 +3,18 @@ package parser
 import (
 	"fmt"
 	"glox/scanner"
+	"slices"
 )
 
 type Parser struct {
 	tokens  []scanner.Token
 	current int32
+	// guard is the position of the first token of the match guard being parsed, or 0 outside of
+	// guards. At the top level of a guard "(...) =>" ends the guard rather than starting an arrow
+	// lambda.
+	guard int32
+	// ternaries holds the positions of the '?' of the ternaries whose ':' is still ahead.
+	ternaries []int32
 }
 
 func New(tokens []scanner.Token) *Parser {
@@ -51,6 +58,13 @@ func (p *Parser) peek() scanner.Token {
 	return p.tokens[p.current]
 }
 
+func (p *Parser) checkNext(tokenType scanner.TokenType) bool {
+	if p.isAtEnd() || p.tokens[p.current+1].Type == scanner.EOF {
+		return false
+	}
+	return p.tokens[p.current+1].Type == tokenType
+}
+
 func (p *Parser) peekBehind() scanner.Token {
 	return p.tokens[p.current-1]
 }
@@ -133,14 +147,21 @@ func (p *Parser) synchronize() {
 		switch p.peek().Type {
 		case scanner.CLASS:
 		case scanner.TRAIT:
+		case scanner.ENUM:
 		case scanner.FUN:
 		case scanner.VAR:
+		case scanner.CONST:
 		case scanner.FOR:
 		case scanner.IF:
 		case scanner.WHILE:
+		case scanner.DO:
+		case scanner.SWITCH:
 		case scanner.BREAK:
 		case scanner.CONTINUE:
 		case scanner.RETURN:
+		case scanner.DEFER:
+		case scanner.USING:
+		case scanner.ASSERT:
 			return
 		}
 
@@ -152,12 +173,24 @@ func (p *Parser) declaration() (Stmt, error) {
 	if p.match(scanner.VAR) {
 		return p.varDecl()
 	}
+	if p.match(scanner.CONST) {
+		return p.constDecl()
+	}
 	if p.match(scanner.CLASS) {
-		return p.classDecl()
+		return p.classDecl(false)
+	}
+	// "abstract" is only special before "class", so it can still be used as a name.
+	if p.check(scanner.IDENTIFIER) && p.peek().Lexeme == "abstract" && p.checkNext(scanner.CLASS) {
+		p.advance()
+		p.advance()
+		return p.classDecl(true)
 	}
 	if p.match(scanner.TRAIT) {
 		return p.traitDecl()
 	}
+	if p.match(scanner.ENUM) {
+		return p.enumDecl()
+	}
 	if p.match(scanner.FUN) {
 		return p.functionDecl("function")
 	}
@@ -166,12 +199,20 @@ func (p *Parser) declaration() (Stmt, error) {
 }
 
 func (p *Parser) varDecl() (Stmt, error) {
+	if p.check(scanner.LEFT_BRACKET) || p.check(scanner.LEFT_BRACE) {
+		return p.destructureDecl()
+	}
+
 	name, err := p.consume(scanner.IDENTIFIER, "Expected identifier after 'var'.")
 	if err != nil {
 		return nil, err
 	}
 	varDecl := VarStmt{Name: name, Initializer: nil}
 
+	if varDecl.Type, err = p.optionalTypeAnnotation(); err != nil {
+		return nil, err
+	}
+
 	if p.match(scanner.EQUAL) {
 		expr, err := p.Expression()
 		if err != nil {
@@ -186,317 +227,931 @@ func (p *Parser) varDecl() (Stmt, error) {
 	return varDecl, nil
 }
 
-func (p *Parser) methodDecl() (Stmt, error) {
-	name, err := p.consume(scanner.IDENTIFIER, fmt.Sprintf("Expteced method or getter name."))
+func (p *Parser) constDecl() (Stmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected identifier after 'const'.")
 	if err != nil {
 		return nil, err
 	}
+	constDecl := VarStmt{Name: name, Constant: true}
+
+	if constDecl.Type, err = p.optionalTypeAnnotation(); err != nil {
+		return nil, err
+	}
 
-	var parameters []scanner.Token = nil
+	if _, err := p.consume(scanner.EQUAL, "Expected '=' after a constant name."); err != nil {
+		return nil, err
+	}
 
-	if p.match(scanner.LEFT_PAREN) {
-		parameters = make([]scanner.Token, 0)
-		if !p.check(scanner.RIGHT_PAREN) {
-			parameters = append(parameters, p.advance())
+	if constDecl.Initializer, err = p.Expression(); err != nil {
+		return nil, err
+	}
 
-			for p.match(scanner.COMMA) {
-				if len(parameters) >= 255 {
-					return nil, p.newError(p.peek(), "Can't have more than 255 parameters.")
-				}
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a constant declaration."); err != nil {
+		return nil, err
+	}
+	return constDecl, nil
+}
 
-				parameters = append(parameters, p.advance())
-			}
-		}
+func (p *Parser) destructureDecl() (Stmt, error) {
+	keyword := p.peekBehind()
 
-		if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after parameters."); err != nil {
-			return nil, err
-		}
+	pattern, err := p.bindingPattern()
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := p.consume(scanner.LEFT_BRACE, fmt.Sprintf("Expected '{' before method or getter body.")); err != nil {
+	if _, err := p.consume(scanner.EQUAL, "Expected '=' after destructuring pattern."); err != nil {
 		return nil, err
 	}
 
-	body, err := p.block()
+	initializer, err := p.Expression()
 	if err != nil {
 		return nil, err
 	}
 
-	return FunctionStmt{Name: name, Parameters: parameters, Body: body.(BlockStmt).Declarations}, nil
-}
-
-func (p *Parser) classMethods() ([]FunctionStmt, []FunctionStmt, error) {
-	methods, staticMethods := make([]FunctionStmt, 0), make([]FunctionStmt, 0)
-	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
-		parsingStaticMethod := p.match(scanner.CLASS)
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a variable declaration."); err != nil {
+		return nil, err
+	}
 
-		methodDecl, err := p.methodDecl()
+	return DestructureStmt{Keyword: keyword, Pattern: pattern, Initializer: initializer}, nil
+}
 
-		if err != nil {
-			return nil, nil, err
-		}
+// bindingPattern parses the patterns allowed in declarations and parameters: names, "_" and
+// array or object patterns made of them.
+func (p *Parser) bindingPattern() (Pattern, error) {
+	if p.match(scanner.LEFT_BRACKET) {
+		return p.arrayPattern(p.bindingPattern)
+	}
 
-		method := methodDecl.(FunctionStmt)
+	if p.match(scanner.LEFT_BRACE) {
+		return p.objectPattern(p.bindingPattern)
+	}
 
-		if parsingStaticMethod {
-			staticMethods = append(staticMethods, method)
-		} else {
-			methods = append(methods, method)
-		}
+	name, err := p.consume(scanner.IDENTIFIER, "Expected variable name in pattern.")
+	if err != nil {
+		return nil, err
 	}
 
-	return methods, staticMethods, nil
+	return BindingPattern{Name: name}, nil
 }
 
-func (p *Parser) classDecl() (Stmt, error) {
-	name, err := p.consume(scanner.IDENTIFIER, "Expected class name.")
-
+func (p *Parser) methodDecl() (Stmt, error) {
+	name, err := p.memberName("Expteced method or getter name.")
 	if err != nil {
 		return nil, err
 	}
 
-	var superclass VariableExpr
+	var parameters []Parameter = nil
 
-	if p.match(scanner.LESS) {
-		name, err := p.consume(scanner.IDENTIFIER, "Excepted superclass name.")
-		if err != nil {
-			return nil, nil
+	if p.match(scanner.LEFT_PAREN) {
+		if parameters, err = p.parameters(); err != nil {
+			return nil, err
 		}
+	}
 
-		superclass.Name = name
+	returnType, err := p.optionalTypeAnnotation()
+	if err != nil {
+		return nil, err
 	}
 
-	traits := make([]VariableExpr, 0)
+	if _, err := p.consume(scanner.LEFT_BRACE, fmt.Sprintf("Expected '{' before method or getter body.")); err != nil {
+		return nil, err
+	}
 
-	if p.match(scanner.USE_TRAIT) {
-		traitName, err := p.consume(scanner.IDENTIFIER, "Excepted trait name.")
-		if err != nil {
-			return nil, nil
-		}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
 
-		traits = append(traits, VariableExpr{Name: traitName})
+	return FunctionStmt{Name: name, Parameters: parameters, ReturnType: returnType, Body: body.(BlockStmt).Declarations}, nil
+}
+
+func (p *Parser) parameters() ([]Parameter, error) {
+	parameters := make([]Parameter, 0)
+	hasDefault := false
+
+	if !p.check(scanner.RIGHT_PAREN) {
+		for {
+			if len(parameters) >= 255 {
+				return nil, p.newError(p.peek(), "Can't have more than 255 parameters.")
+			}
+
+			var parameter Parameter
 
-		for p.match(scanner.COMMA) {
-			traitName, err := p.consume(scanner.IDENTIFIER, "Excepted trait name.")
+			if p.match(scanner.ELLIPSIS) {
+				name, err := p.consume(scanner.IDENTIFIER, "Expected rest parameter name after '...'.")
+				if err != nil {
+					return nil, err
+				}
+				parameter.Name = name
+				parameter.Rest = true
+			} else if p.check(scanner.LEFT_BRACKET) || p.check(scanner.LEFT_BRACE) {
+				parameter.Name = p.peek()
+				pattern, err := p.bindingPattern()
+				if err != nil {
+					return nil, err
+				}
+				parameter.Pattern = pattern
+			} else {
+				name, err := p.consume(scanner.IDENTIFIER, "Expected parameter name.")
+				if err != nil {
+					return nil, err
+				}
+				parameter.Name = name
+			}
+
+			parameterType, err := p.optionalTypeAnnotation()
 			if err != nil {
 				return nil, err
 			}
+			parameter.Type = parameterType
 
-			traits = append(traits, VariableExpr{Name: traitName})
-		}
-	}
+			if !parameter.Rest && p.match(scanner.EQUAL) {
+				if parameter.Default, err = p.Expression(); err != nil {
+					return nil, err
+				}
+				hasDefault = true
+			} else if hasDefault && !parameter.Rest {
+				return nil, p.newError(parameter.Name, "Parameter without a default value can't follow one with a default value.")
+			}
 
-	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before class body."); err != nil {
-		return nil, err
-	}
+			parameters = append(parameters, parameter)
 
-	methods, staticMethods, err := p.classMethods()
+			if parameter.Rest && !p.check(scanner.RIGHT_PAREN) {
+				return nil, p.newError(p.peek(), "Rest parameter must be the last parameter.")
+			}
 
-	if err != nil {
-		return nil, err
+			if !p.match(scanner.COMMA) {
+				break
+			}
+		}
 	}
 
-	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after class body."); err != nil {
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after parameters."); err != nil {
 		return nil, err
 	}
 
-	return ClassStmt{
-		Name:          name,
-		Superclass:    superclass,
-		Traits:        traits,
-		Methods:       methods,
-		StaticMethods: staticMethods,
-	}, nil
+	return parameters, nil
 }
 
-func (p *Parser) traitDecl() (Stmt, error) {
-	name, err := p.consume(scanner.IDENTIFIER, "Expected trait name.")
-
-	if err != nil {
-		return nil, err
+// memberName consumes the name of a property or method, which may be private.
+func (p *Parser) memberName(errorMsg string) (scanner.Token, error) {
+	if p.match(scanner.PRIVATE_IDENTIFIER) {
+		return p.peekBehind(), nil
 	}
 
-	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before trait body."); err != nil {
-		return nil, err
-	}
+	return p.consume(scanner.IDENTIFIER, errorMsg)
+}
 
-	methods, staticMethods, err := p.classMethods()
+func (p *Parser) optionalTypeAnnotation() (*TypeAnnotation, error) {
+	if !p.match(scanner.COLON) {
+		return nil, nil
+	}
 
-	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after trait body."); err != nil {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected type name after ':'.")
+	if err != nil {
 		return nil, err
 	}
 
-	return TraitStmt{
-		Name:          name,
-		Methods:       methods,
-		StaticMethods: staticMethods,
-	}, nil
+	return &TypeAnnotation{Name: name, Nullable: p.match(scanner.QUESTION)}, nil
 }
 
-func (p *Parser) statement() (Stmt, error) {
-	if p.match(scanner.LEFT_BRACE) {
-		return p.block()
-	}
-	if p.match(scanner.PRINT) {
-		return p.printStmt()
-	}
-	if p.match(scanner.IF) {
-		return p.ifStmt()
-	}
-	if p.match(scanner.WHILE) {
-		return p.whileStmt()
-	}
-	if p.match(scanner.FOR) {
-		return p.forStmt()
-	}
-	if p.match(scanner.BREAK, scanner.CONTINUE) {
-		return p.loopInterruptStmts()
-	}
-	if p.match(scanner.RETURN) {
-		return p.returnStmt()
-	}
-
-	return p.expressionStmt()
+// classBody holds the members declared between the braces of a class or trait.
+type classBody struct {
+	methods       []FunctionStmt
+	staticMethods []FunctionStmt
+	setters       []FunctionStmt
+	staticSetters []FunctionStmt
+	fields        []VarStmt
+	staticFields  []VarStmt
+	required      []FunctionStmt
+	abstract      []FunctionStmt
 }
 
-func (p *Parser) functionDecl(kind string) (Stmt, error) {
-	name, err := p.consume(scanner.IDENTIFIER, fmt.Sprintf("Expteced %s name.", kind))
-	if err != nil {
-		return nil, err
+func (p *Parser) classMembers() (classBody, error) {
+	body := classBody{
+		methods:       make([]FunctionStmt, 0),
+		staticMethods: make([]FunctionStmt, 0),
+		setters:       make([]FunctionStmt, 0),
+		staticSetters: make([]FunctionStmt, 0),
+		fields:        make([]VarStmt, 0),
+		staticFields:  make([]VarStmt, 0),
+		required:      make([]FunctionStmt, 0),
+		abstract:      make([]FunctionStmt, 0),
 	}
-	parameters := make([]scanner.Token, 0)
 
-	if _, err := p.consume(scanner.LEFT_PAREN, fmt.Sprintf("Expteced '(' after %s name.", kind)); err != nil {
-		return nil, err
-	}
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		parsingStaticMethod := p.match(scanner.CLASS)
 
-	if !p.check(scanner.RIGHT_PAREN) {
-		parameters = append(parameters, p.advance())
+		if p.match(scanner.VAR) {
+			field, err := p.fieldDecl()
+			if err != nil {
+				return body, err
+			}
 
-		for p.match(scanner.COMMA) {
-			if len(parameters) >= 255 {
-				return nil, p.newError(p.peek(), "Can't have more than 255 parameters.")
+			if parsingStaticMethod {
+				body.staticFields = append(body.staticFields, field)
+			} else {
+				body.fields = append(body.fields, field)
+			}
+			continue
+		}
+
+		// Like "set", "required" and "abstract" are only special before a member name.
+		if p.check(scanner.IDENTIFIER) && (p.peek().Lexeme == "required" || p.peek().Lexeme == "abstract") && p.checkNext(scanner.IDENTIFIER) {
+			keyword := p.advance()
+			if parsingStaticMethod {
+				return body, p.newError(keyword, fmt.Sprintf("Static methods can't be %s.", keyword.Lexeme))
+			}
+
+			method, err := p.bodilessMethod(keyword.Lexeme)
+			if err != nil {
+				return body, err
 			}
 
-			parameters = append(parameters, p.advance())
+			if keyword.Lexeme == "required" {
+				body.required = append(body.required, method)
+			} else {
+				body.abstract = append(body.abstract, method)
+			}
+			continue
 		}
-	}
 
-	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after parameters."); err != nil {
-		return nil, err
-	}
+		// "set" is only special before a member name, so methods can still be called "set".
+		parsingSetter := p.check(scanner.IDENTIFIER) && p.peek().Lexeme == "set" && p.checkNext(scanner.IDENTIFIER)
+		if parsingSetter {
+			p.advance()
+		}
 
-	if _, err := p.consume(scanner.LEFT_BRACE, fmt.Sprintf("Expected '{' before %s body.", kind)); err != nil {
-		return nil, err
-	}
+		methodDecl, err := p.methodDecl()
 
-	body, err := p.block()
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return body, err
+		}
+
+		method := methodDecl.(FunctionStmt)
+
+		switch {
+		case parsingSetter && parsingStaticMethod:
+			body.staticSetters = append(body.staticSetters, method)
+		case parsingSetter:
+			body.setters = append(body.setters, method)
+		case parsingStaticMethod:
+			body.staticMethods = append(body.staticMethods, method)
+		default:
+			body.methods = append(body.methods, method)
+		}
 	}
 
-	return FunctionStmt{Name: name, Parameters: parameters, Body: body.(BlockStmt).Declarations}, nil
+	return body, nil
 }
 
-func (p *Parser) expressionStmt() (Stmt, error) {
-	expr, err := p.Expression()
-
+// bodilessMethod parses a required or abstract method, which is only a signature that another
+// class has to implement.
+func (p *Parser) bodilessMethod(kind string) (FunctionStmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, fmt.Sprintf("Expected %s method name.", kind))
 	if err != nil {
-		return nil, err
+		return FunctionStmt{}, err
 	}
 
-	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a value."); err != nil {
-		return nil, err
+	if _, err := p.consume(scanner.LEFT_PAREN, fmt.Sprintf("Expected '(' after %s method name.", kind)); err != nil {
+		return FunctionStmt{}, err
 	}
 
-	return ExpressionStmt{Expression: expr}, nil
-}
-
-func (p *Parser) printStmt() (Stmt, error) {
-	expr, err := p.Expression()
-
+	parameters, err := p.parameters()
 	if err != nil {
-		return nil, err
+		return FunctionStmt{}, err
 	}
 
-	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a value."); err != nil {
-		return nil, err
+	returnType, err := p.optionalTypeAnnotation()
+	if err != nil {
+		return FunctionStmt{}, err
 	}
 
-	return PrintStmt{Expression: expr}, nil
-}
-
-func (p *Parser) block() (Stmt, error) {
-	declarations := make([]Stmt, 0, 10)
-	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
-		declaration, err := p.declaration()
-		if err != nil {
-			return nil, err
-		}
-		declarations = append(declarations, declaration)
+	article := "a"
+	if kind == "abstract" {
+		article = "an"
 	}
 
-	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after a block."); err != nil {
-		return nil, err
+	if _, err := p.consume(scanner.SEMICOLON, fmt.Sprintf("Expected ';' after %s %s method.", article, kind)); err != nil {
+		return FunctionStmt{}, err
 	}
 
-	return BlockStmt{Declarations: declarations}, nil
+	return FunctionStmt{Name: name, Parameters: parameters, ReturnType: returnType, Body: make([]Stmt, 0)}, nil
 }
 
-func (p *Parser) ifStmt() (Stmt, error) {
-	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'if'."); err != nil {
-		return nil, err
+func (p *Parser) fieldDecl() (VarStmt, error) {
+	name, err := p.memberName("Expected field name.")
+	if err != nil {
+		return VarStmt{}, err
 	}
 
-	expr, err := p.Expression()
+	fieldType, err := p.optionalTypeAnnotation()
 	if err != nil {
-		return nil, err
+		return VarStmt{}, err
 	}
 
-	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'if'."); err != nil {
-		return nil, err
+	var initializer Expr = nil
+	if p.match(scanner.EQUAL) {
+		if initializer, err = p.Expression(); err != nil {
+			return VarStmt{}, err
+		}
 	}
 
-	thenBranch, err := p.statement()
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a field declaration."); err != nil {
+		return VarStmt{}, err
+	}
+
+	return VarStmt{Name: name, Type: fieldType, Initializer: initializer}, nil
+}
+
+func (p *Parser) classDecl(abstract bool) (Stmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected class name.")
+
 	if err != nil {
 		return nil, err
 	}
 
-	var elseBranch Stmt = nil
-	if p.match(scanner.ELSE) {
-		elseBranch, err = p.statement()
+	var superclass VariableExpr
 
+	if p.match(scanner.LESS) {
+		name, err := p.consume(scanner.IDENTIFIER, "Excepted superclass name.")
 		if err != nil {
-			return nil, err
+			return nil, nil
 		}
-	}
 
-	return IfStmt{Expression: expr, ThenBranch: thenBranch, ElseBranch: elseBranch}, nil
-}
-
-func (p *Parser) whileStmt() (Stmt, error) {
-	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'while'."); err != nil {
-		return nil, err
+		superclass.Name = name
 	}
 
-	expr, err := p.Expression()
+	traits, err := p.traitUses()
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'while'."); err != nil {
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before class body."); err != nil {
 		return nil, err
 	}
 
-	stmt, err := p.statement()
+	body, err := p.classMembers()
+
 	if err != nil {
 		return nil, err
 	}
 
-	return WhileStmt{Condition: expr, Body: stmt}, nil
-}
+	if len(body.required) > 0 {
+		return nil, p.newError(body.required[0].Name, "Only traits can declare required methods.")
+	}
+
+	if len(body.abstract) > 0 && !abstract {
+		return nil, p.newError(body.abstract[0].Name, "Only abstract classes can declare abstract methods.")
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after class body."); err != nil {
+		return nil, err
+	}
+
+	return ClassStmt{
+		Name:            name,
+		Abstract:        abstract,
+		Superclass:      superclass,
+		Traits:          traits,
+		AbstractMethods: body.abstract,
+		Methods:         body.methods,
+		StaticMethods:   body.staticMethods,
+		Setters:         body.setters,
+		StaticSetters:   body.staticSetters,
+		Fields:          body.fields,
+		StaticFields:    body.staticFields,
+	}, nil
+}
+
+func (p *Parser) traitUses() ([]TraitUse, error) {
+	traits := make([]TraitUse, 0)
+
+	if !p.match(scanner.USE_TRAIT) {
+		return traits, nil
+	}
+
+	for {
+		use, err := p.traitUse()
+		if err != nil {
+			return nil, err
+		}
+
+		traits = append(traits, use)
+
+		if !p.match(scanner.COMMA) {
+			return traits, nil
+		}
+	}
+}
+
+func (p *Parser) traitUse() (TraitUse, error) {
+	traitName, err := p.consume(scanner.IDENTIFIER, "Excepted trait name.")
+	if err != nil {
+		return TraitUse{}, err
+	}
+
+	use := TraitUse{Trait: VariableExpr{Name: traitName}, Aliases: make([]TraitAlias, 0), Excluded: make([]scanner.Token, 0)}
+
+	if !p.checkTraitAdaptation() {
+		return use, nil
+	}
+	p.advance()
+
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		if p.match(scanner.MINUS) {
+			member, err := p.consume(scanner.IDENTIFIER, "Expected member name after '-'.")
+			if err != nil {
+				return TraitUse{}, err
+			}
+			use.Excluded = append(use.Excluded, member)
+		} else {
+			member, err := p.consume(scanner.IDENTIFIER, "Expected member name.")
+			if err != nil {
+				return TraitUse{}, err
+			}
+
+			if !p.check(scanner.IDENTIFIER) || p.peek().Lexeme != "as" {
+				return TraitUse{}, p.newError(p.peek(), "Expected 'as' after member name.")
+			}
+			p.advance()
+
+			alias, err := p.consume(scanner.IDENTIFIER, "Expected alias after 'as'.")
+			if err != nil {
+				return TraitUse{}, err
+			}
+			use.Aliases = append(use.Aliases, TraitAlias{Member: member, Alias: alias})
+		}
+
+		if !p.match(scanner.COMMA) {
+			break
+		}
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after trait adaptations."); err != nil {
+		return TraitUse{}, err
+	}
+
+	return use, nil
+}
+
+// checkTraitAdaptation tells the braces after a trait name apart from the body of the
+// declaration: adaptations start with "-member" or "member as".
+func (p *Parser) checkTraitAdaptation() bool {
+	if !p.check(scanner.LEFT_BRACE) {
+		return false
+	}
+
+	if p.checkNext(scanner.MINUS) {
+		return true
+	}
+
+	if !p.checkNext(scanner.IDENTIFIER) || int(p.current)+2 >= len(p.tokens) {
+		return false
+	}
+
+	next := p.tokens[p.current+2]
+	return next.Type == scanner.IDENTIFIER && next.Lexeme == "as"
+}
+
+func (p *Parser) traitDecl() (Stmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected trait name.")
+
+	if err != nil {
+		return nil, err
+	}
+
+	traits, err := p.traitUses()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before trait body."); err != nil {
+		return nil, err
+	}
+
+	body, err := p.classMembers()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body.abstract) > 0 {
+		return nil, p.newError(body.abstract[0].Name, "Only abstract classes can declare abstract methods.")
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after trait body."); err != nil {
+		return nil, err
+	}
+
+	return TraitStmt{
+		Name:          name,
+		Traits:        traits,
+		Required:      body.required,
+		Methods:       body.methods,
+		StaticMethods: body.staticMethods,
+		Setters:       body.setters,
+		StaticSetters: body.staticSetters,
+		Fields:        body.fields,
+		StaticFields:  body.staticFields,
+	}, nil
+}
+
+func (p *Parser) enumDecl() (Stmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected enum name.")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before enum body."); err != nil {
+		return nil, err
+	}
+
+	variants := make([]EnumVariant, 0)
+	for !p.check(scanner.RIGHT_BRACE) && !p.check(scanner.SEMICOLON) && !p.isAtEnd() {
+		variant := EnumVariant{}
+		if variant.Name, err = p.consume(scanner.IDENTIFIER, "Expected variant name."); err != nil {
+			return nil, err
+		}
+
+		if p.match(scanner.LEFT_PAREN) {
+			if variant.Parameters, err = p.parameters(); err != nil {
+				return nil, err
+			}
+			for _, parameter := range variant.Parameters {
+				if parameter.Pattern != nil {
+					return nil, p.newError(parameter.Name, "Variant parameters can't be destructured.")
+				}
+			}
+		}
+		variants = append(variants, variant)
+
+		if !p.match(scanner.COMMA) {
+			break
+		}
+	}
+
+	if len(variants) == 0 {
+		return nil, p.newError(p.peek(), "Expected at least one variant in enum body.")
+	}
+
+	body := classBody{methods: make([]FunctionStmt, 0), staticMethods: make([]FunctionStmt, 0)}
+	// Methods follow the variants after a semicolon, e.g. "enum A { X, Y; name() { ... } }".
+	if p.match(scanner.SEMICOLON) {
+		if body, err = p.classMembers(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(body.fields) > 0 || len(body.staticFields) > 0 {
+		field := slices.Concat(body.fields, body.staticFields)[0]
+		return nil, p.newError(field.Name, "Enums can't declare fields.")
+	}
+
+	if len(body.setters) > 0 || len(body.staticSetters) > 0 {
+		setter := slices.Concat(body.setters, body.staticSetters)[0]
+		return nil, p.newError(setter.Name, "Enums can't declare setters.")
+	}
+
+	if len(body.required) > 0 || len(body.abstract) > 0 {
+		method := slices.Concat(body.required, body.abstract)[0]
+		return nil, p.newError(method.Name, "Enums can only declare methods with a body.")
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after enum body."); err != nil {
+		return nil, err
+	}
+
+	return EnumStmt{Name: name, Variants: variants, Methods: body.methods, StaticMethods: body.staticMethods}, nil
+}
+
+func (p *Parser) statement() (Stmt, error) {
+	if p.match(scanner.LEFT_BRACE) {
+		return p.block()
+	}
+	if p.match(scanner.PRINT) {
+		return p.printStmt()
+	}
+	if p.match(scanner.IF) {
+		return p.ifStmt()
+	}
+	if p.match(scanner.WHILE) {
+		return p.whileStmt(scanner.Token{})
+	}
+	if p.match(scanner.FOR) {
+		return p.forStmt(scanner.Token{})
+	}
+	if p.match(scanner.DO) {
+		return p.doWhileStmt(scanner.Token{})
+	}
+	if p.match(scanner.SWITCH) {
+		return p.switchStmt()
+	}
+	// A statement can't otherwise start with "name:", so it's the label of a loop.
+	if p.check(scanner.IDENTIFIER) && p.checkNext(scanner.COLON) {
+		return p.labeledStmt()
+	}
+	if p.match(scanner.BREAK, scanner.CONTINUE) {
+		return p.loopInterruptStmts()
+	}
+	if p.match(scanner.RETURN) {
+		return p.returnStmt()
+	}
+	if p.match(scanner.DEFER) {
+		return p.deferStmt()
+	}
+	if p.match(scanner.USING) {
+		return p.usingStmt()
+	}
+	if p.match(scanner.ASSERT) {
+		return p.assertStmt()
+	}
+	if p.match(scanner.MATCH) {
+		return p.matchStmt()
+	}
+
+	return p.expressionStmt()
+}
+
+func (p *Parser) functionDecl(kind string) (Stmt, error) {
+	name, err := p.consume(scanner.IDENTIFIER, fmt.Sprintf("Expteced %s name.", kind))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consume(scanner.LEFT_PAREN, fmt.Sprintf("Expteced '(' after %s name.", kind)); err != nil {
+		return nil, err
+	}
+
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	returnType, err := p.optionalTypeAnnotation()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_BRACE, fmt.Sprintf("Expected '{' before %s body.", kind)); err != nil {
+		return nil, err
+	}
+
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	return FunctionStmt{Name: name, Parameters: parameters, ReturnType: returnType, Body: body.(BlockStmt).Declarations}, nil
+}
+
+func (p *Parser) expressionStmt() (Stmt, error) {
+	expr, err := p.Expression()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a value."); err != nil {
+		return nil, err
+	}
+
+	return ExpressionStmt{Expression: expr}, nil
+}
+
+func (p *Parser) printStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	expr, err := p.Expression()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a value."); err != nil {
+		return nil, err
+	}
+
+	return PrintStmt{Keyword: keyword, Expression: expr}, nil
+}
+
+func (p *Parser) block() (Stmt, error) {
+	declarations := make([]Stmt, 0, 10)
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		declaration, err := p.declaration()
+		if err != nil {
+			return nil, err
+		}
+		declarations = append(declarations, declaration)
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after a block."); err != nil {
+		return nil, err
+	}
+
+	return BlockStmt{Declarations: declarations}, nil
+}
+
+func (p *Parser) ifStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'if'."); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'if'."); err != nil {
+		return nil, err
+	}
+
+	thenBranch, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	var elseBranch Stmt = nil
+	if p.match(scanner.ELSE) {
+		elseBranch, err = p.statement()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return IfStmt{Keyword: keyword, Expression: expr, ThenBranch: thenBranch, ElseBranch: elseBranch}, nil
+}
+
+func (p *Parser) labeledStmt() (Stmt, error) {
+	label := p.advance()
+	p.advance()
+
+	switch {
+	case p.match(scanner.WHILE):
+		return p.whileStmt(label)
+	case p.match(scanner.FOR):
+		return p.forStmt(label)
+	case p.match(scanner.DO):
+		return p.doWhileStmt(label)
+	}
+
+	return nil, p.newError(p.peek(), "Expected a loop after label.")
+}
+
+func (p *Parser) whileStmt(label scanner.Token) (Stmt, error) {
+	keyword := p.peekBehind()
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'while'."); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'while'."); err != nil {
+		return nil, err
+	}
+
+	stmt, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	return WhileStmt{Keyword: keyword, Condition: expr, Body: stmt, Label: label}, nil
+}
+
+func (p *Parser) doWhileStmt(label scanner.Token) (Stmt, error) {
+	keyword := p.peekBehind()
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.WHILE, "Expected 'while' after 'do' body."); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'while'."); err != nil {
+		return nil, err
+	}
+
+	condition, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'while'."); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after a 'do' loop."); err != nil {
+		return nil, err
+	}
+
+	return DoWhileStmt{Keyword: keyword, Body: body, Condition: condition, Label: label}, nil
+}
+
+func (p *Parser) switchStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'switch'."); err != nil {
+		return nil, err
+	}
+
+	subject, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after switch value."); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before switch cases."); err != nil {
+		return nil, err
+	}
+
+	stmt := SwitchStmt{Keyword: keyword, Subject: subject, Cases: make([]SwitchCase, 0)}
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		if p.checkDefaultCase() {
+			if stmt.Default != nil {
+				return nil, p.newError(p.peek(), "A switch can only have one default case.")
+			}
+			p.advance()
+			p.advance()
+
+			if stmt.Default, err = p.switchCaseBody(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		caseKeyword, err := p.consume(scanner.CASE, "Expected 'case' or 'default' inside of switch.")
+		if err != nil {
+			return nil, err
+		}
+
+		switchCase := SwitchCase{Keyword: caseKeyword, Values: make([]Expr, 0)}
+		for {
+			value, err := p.Expression()
+			if err != nil {
+				return nil, err
+			}
+			switchCase.Values = append(switchCase.Values, value)
+
+			if !p.match(scanner.COMMA) {
+				break
+			}
+		}
+
+		if _, err := p.consume(scanner.COLON, "Expected ':' after case values."); err != nil {
+			return nil, err
+		}
+
+		if switchCase.Body, err = p.switchCaseBody(); err != nil {
+			return nil, err
+		}
+		stmt.Cases = append(stmt.Cases, switchCase)
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after switch cases."); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// checkDefaultCase reports whether the next tokens are "default:". Like "set", "default" is only
+// special in this position.
+func (p *Parser) checkDefaultCase() bool {
+	return p.check(scanner.IDENTIFIER) && p.peek().Lexeme == "default" && p.checkNext(scanner.COLON)
+}
+
+// switchCaseBody parses the statements of a case up to the next case, the default case or the end
+// of the switch.
+func (p *Parser) switchCaseBody() ([]Stmt, error) {
+	body := make([]Stmt, 0)
+
+	for !p.check(scanner.CASE) && !p.checkDefaultCase() && !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		stmt, err := p.declaration()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmt)
+	}
+
+	return body, nil
+}
+
+func (p *Parser) forStmt(label scanner.Token) (Stmt, error) {
+	keyword := p.peekBehind()
 
-func (p *Parser) forStmt() (Stmt, error) {
 	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'for'."); err != nil {
 		return nil, err
 	}
@@ -504,101 +1159,449 @@ func (p *Parser) forStmt() (Stmt, error) {
 	var initializer Stmt
 	var err error = nil
 
-	if p.match(scanner.SEMICOLON) {
-		initializer = nil
-	} else if p.match(scanner.VAR) {
-		initializer, err = p.varDecl()
-	} else {
-		initializer, err = p.expressionStmt()
+	if p.match(scanner.SEMICOLON) {
+		initializer = nil
+	} else if p.match(scanner.VAR) {
+		initializer, err = p.varDecl()
+	} else {
+		initializer, err = p.expressionStmt()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var condition Expr = nil
+
+	if !p.match(scanner.SEMICOLON) {
+		condition, err = p.Expression()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after condition."); err != nil {
+			return nil, err
+		}
+	}
+
+	var increment Stmt = nil
+
+	if !p.match(scanner.RIGHT_PAREN) {
+		incrementExpr, err := p.Expression()
+
+		if err != nil {
+			return nil, err
+		}
+
+		increment = ExpressionStmt{Expression: incrementExpr}
+
+		if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'for'."); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	return BlockStmt{Declarations: []Stmt{ForStmt{Keyword: keyword, Initializer: initializer, Condition: condition, Increment: increment, Body: body, Label: label}}}, nil
+
+	// Desugar into a while.
+
+	//whileBody := []Stmt{body}
+	//if increment != nil {
+	//	whileBody = append(whileBody, ExpressionStmt{Expression: increment})
+	//}
+	//
+	//whileStmt := WhileStmt{Condition: condition, Body: BlockStmt{Declarations: whileBody}}
+	//
+	//whileBlock := BlockStmt{Declarations: make([]Stmt, 0)}
+	//if initializer != nil {
+	//	whileBlock.Declarations = append(whileBlock.Declarations, initializer)
+	//}
+	//whileBlock.Declarations = append(whileBlock.Declarations, whileStmt)
+	//
+	//return whileBlock, nil
+}
+
+func (p *Parser) loopInterruptStmts() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	var label scanner.Token
+	if p.match(scanner.IDENTIFIER) {
+		label = p.peekBehind()
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, fmt.Sprintf("Expected ';' after a '%s'.", keyword.Lexeme)); err != nil {
+		return nil, err
+	}
+	if keyword.Type == scanner.BREAK {
+		return BreakStmt{Keyword: keyword, Label: label}, nil
+	}
+	return ContinueStmt{Keyword: keyword, Label: label}, nil
+}
+
+func (p *Parser) returnStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	var expr Expr = nil
+	var err error = nil
+	if !p.check(scanner.SEMICOLON) {
+		if expr, err = p.Expression(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, fmt.Sprintf("Expected ';' after a '%s'.", keyword.Lexeme)); err != nil {
+		return nil, err
+	}
+
+	return ReturnStmt{Keyword: keyword, Expr: expr}, nil
+}
+
+func (p *Parser) deferStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	expr, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after deferred expression."); err != nil {
+		return nil, err
+	}
+
+	return DeferStmt{Keyword: keyword, Expr: expr}, nil
+}
+
+func (p *Parser) usingStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'using'."); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.VAR, "Expected 'var' before resource name."); err != nil {
+		return nil, err
+	}
+
+	name, err := p.consume(scanner.IDENTIFIER, "Expected resource name.")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.EQUAL, "Expected '=' after resource name."); err != nil {
+		return nil, err
+	}
+
+	initializer, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after resource."); err != nil {
+		return nil, err
+	}
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	return UsingStmt{Keyword: keyword, Name: name, Initializer: initializer, Body: body}, nil
+}
+
+func (p *Parser) assertStmt() (Stmt, error) {
+	keyword := p.peekBehind()
+
+	condition, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	var message Expr = nil
+	if p.match(scanner.COMMA) {
+		if message, err = p.Expression(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after assertion."); err != nil {
+		return nil, err
+	}
+
+	return AssertStmt{Keyword: keyword, Condition: condition, Message: message}, nil
+}
+
+func (p *Parser) matchStmt() (Stmt, error) {
+	expr, err := p.matchExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	// A match used as a statement ends with '}', so the semicolon is optional.
+	p.match(scanner.SEMICOLON)
+
+	return ExpressionStmt{Expression: expr}, nil
+}
+
+func (p *Parser) matchExpr() (Expr, error) {
+	keyword := p.peekBehind()
+
+	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' after 'match'."); err != nil {
+		return nil, err
+	}
+
+	subject, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after match value."); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before match cases."); err != nil {
+		return nil, err
+	}
+
+	cases := make([]MatchCase, 0)
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		matchCase, err := p.matchCase()
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, matchCase)
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after match cases."); err != nil {
+		return nil, err
+	}
+
+	return MatchExpr{Keyword: keyword, Subject: subject, Cases: cases}, nil
+}
+
+func (p *Parser) matchCase() (MatchCase, error) {
+	keyword, err := p.consume(scanner.CASE, "Expected 'case' inside of match.")
+	if err != nil {
+		return MatchCase{}, err
+	}
+
+	pattern, err := p.pattern()
+	if err != nil {
+		return MatchCase{}, err
+	}
+
+	matchCase := MatchCase{Keyword: keyword, Pattern: pattern}
+
+	if p.match(scanner.IF) {
+		enclosing := p.guard
+		p.guard = p.current
+		matchCase.Guard, err = p.Expression()
+		p.guard = enclosing
+		if err != nil {
+			return MatchCase{}, err
+		}
+	}
+
+	if _, err := p.consume(scanner.ARROW, "Expected '=>' after case pattern."); err != nil {
+		return MatchCase{}, err
+	}
+
+	if p.match(scanner.LEFT_BRACE) {
+		block, err := p.block()
+		if err != nil {
+			return MatchCase{}, err
+		}
+		matchCase.Block = block.(BlockStmt).Declarations
+	} else if matchCase.Body, err = p.Expression(); err != nil {
+		return MatchCase{}, err
+	}
+
+	if !p.match(scanner.COMMA) {
+		p.match(scanner.SEMICOLON)
+	}
+
+	return matchCase, nil
+}
+
+func (p *Parser) literalPattern() (any, error) {
+	negative := p.match(scanner.MINUS)
+
+	if negative && !p.check(scanner.NUMBER) {
+		return nil, p.newError(p.peek(), "Expected a number after '-' in pattern.")
+	}
+
+	if !p.match(scanner.NUMBER, scanner.STRING, scanner.TRUE, scanner.FALSE, scanner.NIL) {
+		return nil, p.newError(p.peek(), "Expected a literal in pattern.")
+	}
+
+	token := p.peekBehind()
+
+	switch token.Type {
+	case scanner.TRUE:
+		return true, nil
+	case scanner.FALSE:
+		return false, nil
+	case scanner.NIL:
+		return nil, nil
+	}
+
+	if !negative {
+		return token.Literal, nil
+	}
+
+	switch value := token.Literal.(type) {
+	case scanner.BigIntLiteral:
+		return scanner.BigIntLiteral("-" + value), nil
+	case scanner.DecimalLiteral:
+		return scanner.DecimalLiteral("-" + value), nil
+	}
+
+	return -token.Literal.(float64), nil
+}
+
+func (p *Parser) pattern() (Pattern, error) {
+	if p.match(scanner.LEFT_BRACKET) {
+		return p.arrayPattern(p.pattern)
+	}
+
+	if p.match(scanner.LEFT_BRACE) {
+		return p.objectPattern(p.pattern)
+	}
+
+	if p.match(scanner.IDENTIFIER) {
+		name := p.peekBehind()
+		if !p.check(scanner.DOT) && !p.check(scanner.LEFT_PAREN) {
+			return BindingPattern{Name: name}, nil
+		}
+
+		var value Expr = VariableExpr{Name: name}
+		for p.match(scanner.DOT) {
+			property, err := p.consume(scanner.IDENTIFIER, "Expected property name after '.'.")
+			if err != nil {
+				return nil, err
+			}
+			value = GetExpr{Object: value, Name: property}
+		}
+
+		if !p.match(scanner.LEFT_PAREN) {
+			return ValuePattern{Value: value}, nil
+		}
+
+		return p.classPattern(value)
 	}
 
+	token := p.peek()
+	start, err := p.literalPattern()
 	if err != nil {
 		return nil, err
 	}
 
-	var condition Expr = nil
-
-	if !p.match(scanner.SEMICOLON) {
-		condition, err = p.Expression()
+	if p.match(scanner.DOT_DOT, scanner.DOT_DOT_LESS) {
+		inclusive := p.peekBehind().Type == scanner.DOT_DOT
 
+		end, err := p.literalPattern()
 		if err != nil {
 			return nil, err
 		}
 
-		if _, err := p.consume(scanner.SEMICOLON, "Expected ';' after condition."); err != nil {
-			return nil, err
-		}
+		return RangePattern{Token: token, Start: start, End: end, Inclusive: inclusive}, nil
 	}
 
-	var increment Stmt = nil
+	return LiteralPattern{Token: token, Value: start}, nil
+}
 
-	if !p.match(scanner.RIGHT_PAREN) {
-		incrementExpr, err := p.Expression()
+func (p *Parser) arrayPattern(element func() (Pattern, error)) (Pattern, error) {
+	bracket := p.peekBehind()
+	elements := make([]Pattern, 0)
+	var rest *scanner.Token
+
+	for !p.check(scanner.RIGHT_BRACKET) && !p.isAtEnd() {
+		if p.match(scanner.ELLIPSIS) {
+			name, err := p.consume(scanner.IDENTIFIER, "Expected name after '...'.")
+			if err != nil {
+				return nil, err
+			}
+			rest = &name
+
+			if !p.check(scanner.RIGHT_BRACKET) {
+				return nil, p.newError(p.peek(), "Rest element must be last in array pattern.")
+			}
+			break
+		}
 
+		elementPattern, err := element()
 		if err != nil {
 			return nil, err
 		}
+		elements = append(elements, elementPattern)
 
-		increment = ExpressionStmt{Expression: incrementExpr}
-
-		if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' at the end of 'for'."); err != nil {
-			return nil, err
+		if !p.match(scanner.COMMA) {
+			break
 		}
 	}
 
-	body, err := p.statement()
-	if err != nil {
+	if _, err := p.consume(scanner.RIGHT_BRACKET, "Expected ']' after array pattern."); err != nil {
 		return nil, err
 	}
 
-	return BlockStmt{Declarations: []Stmt{ForStmt{Initializer: initializer, Condition: condition, Increment: increment, Body: body}}}, nil
+	return ArrayPattern{Bracket: bracket, Elements: elements, Rest: rest}, nil
+}
 
-	// Desugar into a while.
+func (p *Parser) objectPattern(field func() (Pattern, error)) (Pattern, error) {
+	brace := p.peekBehind()
+	fields := make([]ObjectPatternField, 0)
 
-	//whileBody := []Stmt{body}
-	//if increment != nil {
-	//	whileBody = append(whileBody, ExpressionStmt{Expression: increment})
-	//}
-	//
-	//whileStmt := WhileStmt{Condition: condition, Body: BlockStmt{Declarations: whileBody}}
-	//
-	//whileBlock := BlockStmt{Declarations: make([]Stmt, 0)}
-	//if initializer != nil {
-	//	whileBlock.Declarations = append(whileBlock.Declarations, initializer)
-	//}
-	//whileBlock.Declarations = append(whileBlock.Declarations, whileStmt)
-	//
-	//return whileBlock, nil
-}
+	for !p.check(scanner.RIGHT_BRACE) && !p.isAtEnd() {
+		name, err := p.consume(scanner.IDENTIFIER, "Expected property name in object pattern.")
+		if err != nil {
+			return nil, err
+		}
 
-func (p *Parser) loopInterruptStmts() (Stmt, error) {
-	keyword := p.peekBehind()
+		var fieldPattern Pattern = BindingPattern{Name: name}
+		if p.match(scanner.COLON) {
+			if fieldPattern, err = field(); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, ObjectPatternField{Name: name, Pattern: fieldPattern})
 
-	if _, err := p.consume(scanner.SEMICOLON, fmt.Sprintf("Expected ';' after a '%s'.", keyword.Lexeme)); err != nil {
-		return nil, err
+		if !p.match(scanner.COMMA) {
+			break
+		}
 	}
-	if keyword.Type == scanner.BREAK {
-		return BreakStmt{Keyword: keyword}, nil
+
+	if _, err := p.consume(scanner.RIGHT_BRACE, "Expected '}' after object pattern."); err != nil {
+		return nil, err
 	}
-	return ContinueStmt{Keyword: keyword}, nil
+
+	return ObjectPattern{Brace: brace, Fields: fields}, nil
 }
 
-func (p *Parser) returnStmt() (Stmt, error) {
-	keyword := p.peekBehind()
+func (p *Parser) classPattern(class Expr) (Pattern, error) {
+	arguments := make([]Pattern, 0)
 
-	var expr Expr = nil
-	var err error = nil
-	if !p.check(scanner.SEMICOLON) {
-		if expr, err = p.Expression(); err != nil {
+	for !p.check(scanner.RIGHT_PAREN) && !p.isAtEnd() {
+		argument, err := p.pattern()
+		if err != nil {
 			return nil, err
 		}
+		arguments = append(arguments, argument)
+
+		if !p.match(scanner.COMMA) {
+			break
+		}
 	}
 
-	if _, err := p.consume(scanner.SEMICOLON, fmt.Sprintf("Expected ';' after a '%s'.", keyword.Lexeme)); err != nil {
+	parenthesis, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after class pattern.")
+	if err != nil {
 		return nil, err
 	}
 
-	return ReturnStmt{Keyword: keyword, Expr: expr}, nil
+	return ClassPattern{Class: class, Parenthesis: parenthesis, Arguments: arguments}, nil
 }
 
 func (p *Parser) Expression() (Expr, error) {
@@ -614,8 +1617,11 @@ func (p *Parser) ternary() (Expr, error) {
 	if !p.match(scanner.QUESTION) {
 		return condition, err
 	}
+	question := p.peekBehind()
 
+	p.ternaries = append(p.ternaries, p.current-1)
 	left, err := p.ternary()
+	p.ternaries = p.ternaries[:len(p.ternaries)-1]
 	if err != nil {
 		return nil, err
 	}
@@ -629,11 +1635,11 @@ func (p *Parser) ternary() (Expr, error) {
 		return nil, err
 	}
 
-	return TernaryExpr{Condition: condition, Left: left, Right: right}, nil
+	return TernaryExpr{Condition: condition, Question: question, Left: left, Right: right}, nil
 }
 
 func (p *Parser) assignment() (Expr, error) {
-	expr, err := p.logicalOr()
+	expr, err := p.pipeline()
 	if err != nil {
 		return nil, err
 	}
@@ -652,6 +1658,13 @@ func (p *Parser) assignment() (Expr, error) {
 			return SetExpr{Object: t.Object, Name: t.Name, Value: value}, nil
 		case ArrayGetExpr:
 			return ArraySetExpr{Array: t.Array, Bracket: t.Bracket, Index: t.Index, Value: value}, nil
+		case ArrayExpr:
+			if err := p.validateDestructuringTarget(t, equals); err != nil {
+				return nil, err
+			}
+
+			targets, rest := SplitRest(t.Elements)
+			return DestructureAssignmentExpr{Bracket: t.Bracket, Targets: targets, Rest: rest, Value: value}, nil
 		default:
 			return nil, p.newError(equals, "Invalid assignment target.")
 		}
@@ -660,6 +1673,70 @@ func (p *Parser) assignment() (Expr, error) {
 	return expr, nil
 }
 
+func (p *Parser) validateDestructuringTarget(target Expr, equals scanner.Token) error {
+	switch t := target.(type) {
+	case VariableExpr, GetExpr, ArrayGetExpr:
+		return nil
+	case ArrayExpr:
+		for idx, element := range t.Elements {
+			if spread, ok := element.(SpreadExpr); ok {
+				if idx != len(t.Elements)-1 {
+					return p.newError(spread.Ellipsis, "Rest element must be last in array pattern.")
+				}
+				element = spread.Expr
+			}
+
+			if err := p.validateDestructuringTarget(element, equals); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return p.newError(equals, "Invalid assignment target.")
+}
+
+// pipeline parses "value |> f(a)", which is lowered to the call "f(value, a)". A right side that
+// isn't a call is called with the value as its only argument.
+func (p *Parser) pipeline() (Expr, error) {
+	expr, err := p.nullCoalescing()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(scanner.PIPE_GREATER) {
+		pipe := p.peekBehind()
+
+		right, err := p.nullCoalescing()
+		if err != nil {
+			return nil, err
+		}
+
+		expr = pipeInto(expr, right, pipe)
+	}
+
+	return expr, nil
+}
+
+func pipeInto(value Expr, target Expr, pipe scanner.Token) Expr {
+	switch target := target.(type) {
+	case CallExpr:
+		target.Arguments = slices.Concat([]Expr{value}, target.Arguments)
+		return target
+	case OptionalChainExpr:
+		if call, ok := target.Expr.(CallExpr); ok {
+			target.Expr = pipeInto(value, call, pipe)
+			return target
+		}
+	}
+
+	return CallExpr{Callee: target, Parenthesis: pipe, Arguments: []Expr{value}, NamedArguments: make([]NamedArgument, 0)}
+}
+
+func (p *Parser) nullCoalescing() (Expr, error) {
+	return p.parseLogicalExpr(p.logicalOr, scanner.QUESTION_QUESTION)
+}
+
 func (p *Parser) logicalOr() (Expr, error) {
 	return p.parseLogicalExpr(p.logicalAnd, scanner.OR)
 }
@@ -673,7 +1750,36 @@ func (p *Parser) equality() (Expr, error) {
 }
 
 func (p *Parser) comparison() (Expr, error) {
-	return p.parseBinaryExpr(p.modulo, scanner.GREATER, scanner.GREATER_EQUAL, scanner.LESS, scanner.LESS_EQUAL)
+	return p.parseBinaryExpr(p.rangeExpr, scanner.GREATER, scanner.GREATER_EQUAL, scanner.LESS, scanner.LESS_EQUAL, scanner.IS)
+}
+
+// rangeExpr parses "start..end" and "start..<end", with an optional "step" after the end. Like
+// "set", "step" is only special in this position.
+func (p *Parser) rangeExpr() (Expr, error) {
+	start, err := p.modulo()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.match(scanner.DOT_DOT, scanner.DOT_DOT_LESS) {
+		return start, nil
+	}
+	operator := p.peekBehind()
+
+	end, err := p.modulo()
+	if err != nil {
+		return nil, err
+	}
+
+	var step Expr = nil
+	if p.check(scanner.IDENTIFIER) && p.peek().Lexeme == "step" {
+		p.advance()
+		if step, err = p.modulo(); err != nil {
+			return nil, err
+		}
+	}
+
+	return RangeExpr{Start: start, Operator: operator, End: end, Step: step}, nil
 }
 
 func (p *Parser) modulo() (Expr, error) {
@@ -704,36 +1810,46 @@ func (p *Parser) unary() (Expr, error) {
 
 func (p *Parser) finishCall(callee Expr) (Expr, error) {
 	arguments := make([]Expr, 0)
+	namedArguments := make([]NamedArgument, 0)
 
-	if p.match(scanner.RIGHT_PAREN) {
-		return CallExpr{Callee: callee, Parenthesis: p.peekBehind(), Arguments: arguments}, nil
-	}
+	if !p.check(scanner.RIGHT_PAREN) {
+		for {
+			if len(arguments)+len(namedArguments) >= 255 {
+				return nil, p.newError(p.peek(), "Can't have more than 255 arguments.")
+			}
 
-	firstArg, err := p.Expression()
-	if err != nil {
-		return nil, err
-	}
+			if p.check(scanner.IDENTIFIER) && p.checkNext(scanner.COLON) {
+				name := p.advance()
+				p.advance()
 
-	arguments = append(arguments, firstArg)
+				value, err := p.Expression()
+				if err != nil {
+					return nil, err
+				}
+				namedArguments = append(namedArguments, NamedArgument{Name: name, Value: value})
+			} else {
+				if len(namedArguments) != 0 {
+					return nil, p.newError(p.peek(), "Positional arguments can't follow named arguments.")
+				}
 
-	for p.match(scanner.COMMA) {
-		if len(arguments) >= 255 {
-			return nil, p.newError(p.peek(), "Can't have more than 255 arguments.")
-		}
+				arg, err := p.spreadable()
+				if err != nil {
+					return nil, err
+				}
+				arguments = append(arguments, arg)
+			}
 
-		arg, err := p.Expression()
-		if err != nil {
-			return nil, err
+			if !p.match(scanner.COMMA) {
+				break
+			}
 		}
-
-		arguments = append(arguments, arg)
 	}
 
 	if _, err := p.consume(scanner.RIGHT_PAREN, "Expected ')' after arguments."); err != nil {
 		return nil, err
 	}
 
-	return CallExpr{Callee: callee, Parenthesis: p.peekBehind(), Arguments: arguments}, nil
+	return CallExpr{Callee: callee, Parenthesis: p.peekBehind(), Arguments: arguments, NamedArguments: namedArguments}, nil
 }
 
 func (p *Parser) call() (Expr, error) {
@@ -743,7 +1859,15 @@ func (p *Parser) call() (Expr, error) {
 		return nil, err
 	}
 
+	// An optional link short-circuits the rest of the chain, so the chain is wrapped once it has one.
+	optional := false
+
 	for {
+		isOptional := p.checkOptionalIndex()
+		if isOptional {
+			p.advance()
+		}
+
 		if p.match(scanner.LEFT_BRACKET) {
 			bracket := p.peekBehind()
 
@@ -756,7 +1880,8 @@ func (p *Parser) call() (Expr, error) {
 				return nil, err
 			}
 
-			expr = ArrayGetExpr{Array: expr, Bracket: bracket, Index: index}
+			optional = optional || isOptional
+			expr = ArrayGetExpr{Array: expr, Bracket: bracket, Index: index, Optional: isOptional}
 
 		} else if p.match(scanner.LEFT_PAREN) {
 			if expr, err = p.finishCall(expr); err != nil {
@@ -764,7 +1889,7 @@ func (p *Parser) call() (Expr, error) {
 			}
 
 		} else if p.match(scanner.DOT) {
-			name, err := p.consume(scanner.IDENTIFIER, "Expected property name after '.'.")
+			name, err := p.memberName("Expected property name after '.'.")
 
 			if err != nil {
 				return nil, err
@@ -772,49 +1897,199 @@ func (p *Parser) call() (Expr, error) {
 
 			expr = GetExpr{Object: expr, Name: name}
 
+		} else if p.match(scanner.QUESTION_DOT) {
+			optional = true
+
+			if p.match(scanner.LEFT_PAREN) {
+				if expr, err = p.finishCall(expr); err != nil {
+					return nil, err
+				}
+				call := expr.(CallExpr)
+				call.Optional = true
+				expr = call
+				continue
+			}
+
+			name, err := p.consume(scanner.IDENTIFIER, "Expected property name after '?.'.")
+			if err != nil {
+				return nil, err
+			}
+
+			expr = GetExpr{Object: expr, Name: name, Optional: true}
+
 		} else {
 			break
 		}
 	}
 
+	if optional {
+		return OptionalChainExpr{Expr: expr}, nil
+	}
+
 	return expr, nil
 }
 
+// checkOptionalIndex tells an optional index like "a?[0]" apart from a ternary with an array
+// branch like "a?[0]:[1]" by looking for the ':' of the '?'. A ':' that an enclosing ternary
+// still waits for doesn't count, so "a ? b?[0] : c" indexes b optionally.
+func (p *Parser) checkOptionalIndex() bool {
+	if !p.check(scanner.QUESTION) || !p.checkNext(scanner.LEFT_BRACKET) {
+		return false
+	}
+
+	waiting := 0
+	for _, question := range p.ternaries {
+		if p.nesting(question, p.current) == 0 {
+			waiting++
+		}
+	}
+
+	// Like brackets, each ':' belongs to the nearest '?' before it that has none yet.
+	questions, colons, depth := 0, 0, 0
+	for idx := int(p.current) + 1; idx < len(p.tokens); idx++ {
+		switch p.tokens[idx].Type {
+		case scanner.LEFT_PAREN, scanner.LEFT_BRACKET, scanner.LEFT_BRACE:
+			depth++
+		case scanner.RIGHT_PAREN, scanner.RIGHT_BRACKET, scanner.RIGHT_BRACE:
+			if depth == 0 {
+				return colons <= waiting
+			}
+			depth--
+		case scanner.QUESTION:
+			if depth == 0 {
+				questions++
+			}
+		case scanner.COLON:
+			if depth == 0 && questions > 0 {
+				questions--
+			} else if depth == 0 {
+				colons++
+			}
+		case scanner.SEMICOLON, scanner.COMMA, scanner.ARROW, scanner.EOF:
+			if depth == 0 {
+				return colons <= waiting
+			}
+		}
+	}
+
+	return colons <= waiting
+}
+
+// nesting returns how many more brackets, parentheses and braces are opened than closed between
+// the tokens at from and to.
+func (p *Parser) nesting(from int32, to int32) int {
+	depth := 0
+	for idx := from; idx < to; idx++ {
+		switch p.tokens[idx].Type {
+		case scanner.LEFT_PAREN, scanner.LEFT_BRACKET, scanner.LEFT_BRACE:
+			depth++
+		case scanner.RIGHT_PAREN, scanner.RIGHT_BRACKET, scanner.RIGHT_BRACE:
+			depth--
+		}
+	}
+
+	return depth
+}
+
 func (p *Parser) lambda() (Expr, error) {
 	if _, err := p.consume(scanner.LEFT_PAREN, "Expected '(' before anonymous function parameters"); err != nil {
 		return nil, err
 	}
 
-	parameters := make([]scanner.Token, 0)
-	var parenthesisToken scanner.Token
-	var err error
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+	parenthesisToken := p.peekBehind()
+
+	returnType, err := p.optionalTypeAnnotation()
+	if err != nil {
+		return nil, err
+	}
 
-	if p.match(scanner.RIGHT_PAREN) {
-		parenthesisToken = p.peekBehind()
-	} else {
-		firstParam := p.advance()
-		parameters = append(parameters, firstParam)
+	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before anonymous function body."); err != nil {
+		return nil, err
+	}
+
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	return LambdaExpr{Parenthesis: parenthesisToken, Parameters: parameters, ReturnType: returnType, Body: body.(BlockStmt).Declarations}, nil
+}
 
-		for p.match(scanner.COMMA) {
-			parameters = append(parameters, p.advance())
+// checkArrowLambda tells an arrow lambda like "(x) => x * 2" apart from a grouping by looking for
+// "=>" after the closing parenthesis. Match guards end right before "=>", so arrow lambdas aren't
+// recognized at their top level, only within parentheses, brackets or braces.
+func (p *Parser) checkArrowLambda() bool {
+	if p.guard > 0 && p.nesting(p.guard, p.current) == 0 {
+		return false
+	}
+
+	depth := 0
+	for idx := int(p.current); idx < len(p.tokens); idx++ {
+		switch p.tokens[idx].Type {
+		case scanner.LEFT_PAREN:
+			depth++
+		case scanner.RIGHT_PAREN:
+			depth--
+			if depth == 0 {
+				return idx+1 < len(p.tokens) && p.tokens[idx+1].Type == scanner.ARROW
+			}
+		case scanner.EOF:
+			return false
 		}
+	}
+
+	return false
+}
 
-		parenthesisToken, err = p.consume(scanner.RIGHT_PAREN, "Expected ')' after anonymous function parameters.")
+// arrowLambda parses "(parameters) => body", where body is either a block or an expression
+// whose value is returned. Both are lowered to a LambdaExpr.
+func (p *Parser) arrowLambda() (Expr, error) {
+	p.advance()
+
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+	parenthesisToken := p.peekBehind()
+
+	arrow, err := p.consume(scanner.ARROW, "Expected '=>' after lambda parameters.")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(scanner.LEFT_BRACE) {
+		body, err := p.block()
 		if err != nil {
 			return nil, err
 		}
+
+		return LambdaExpr{Parenthesis: parenthesisToken, Parameters: parameters, Body: body.(BlockStmt).Declarations}, nil
 	}
 
-	if _, err := p.consume(scanner.LEFT_BRACE, "Expected '{' before anonymous function body."); err != nil {
+	value, err := p.Expression()
+	if err != nil {
 		return nil, err
 	}
 
-	body, err := p.block()
+	return LambdaExpr{Parenthesis: parenthesisToken, Parameters: parameters, Body: []Stmt{ReturnStmt{Keyword: arrow, Expr: value}}}, nil
+}
+
+func (p *Parser) spreadable() (Expr, error) {
+	if !p.match(scanner.ELLIPSIS) {
+		return p.Expression()
+	}
+
+	ellipsis := p.peekBehind()
+	expr, err := p.Expression()
 	if err != nil {
 		return nil, err
 	}
 
-	return LambdaExpr{Parenthesis: parenthesisToken, Parameters: parameters, Body: body.(BlockStmt).Declarations}, nil
+	return SpreadExpr{Ellipsis: ellipsis, Expr: expr}, nil
 }
 
 func (p *Parser) array() (Expr, error) {
@@ -824,14 +2099,18 @@ func (p *Parser) array() (Expr, error) {
 		return ArrayExpr{Elements: elements, Bracket: p.peekBehind()}, nil
 	}
 
-	firstElem, err := p.Expression()
+	firstElem, err := p.spreadable()
 	if err != nil {
 		return nil, err
 	}
+
+	if _, isSpread := firstElem.(SpreadExpr); !isSpread && p.match(scanner.FOR) {
+		return p.comprehension(firstElem)
+	}
 	elements = append(elements, firstElem)
 
 	for p.match(scanner.COMMA) {
-		elem, err := p.Expression()
+		elem, err := p.spreadable()
 		if err != nil {
 			return nil, err
 		}
@@ -845,6 +2124,37 @@ func (p *Parser) array() (Expr, error) {
 	return ArrayExpr{Elements: elements, Bracket: p.peekBehind()}, nil
 }
 
+// comprehension parses the rest of "[element for name in iterable if condition]" after the element.
+func (p *Parser) comprehension(element Expr) (Expr, error) {
+	name, err := p.consume(scanner.IDENTIFIER, "Expected variable name after 'for'.")
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.check(scanner.IDENTIFIER) || p.peek().Lexeme != "in" {
+		return nil, p.newError(p.peek(), "Expected 'in' after comprehension variable.")
+	}
+	p.advance()
+
+	iterable, err := p.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	var condition Expr = nil
+	if p.match(scanner.IF) {
+		if condition, err = p.Expression(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.consume(scanner.RIGHT_BRACKET, "Expected ']' after comprehension."); err != nil {
+		return nil, err
+	}
+
+	return ComprehensionExpr{Bracket: p.peekBehind(), Element: element, Name: name, Iterable: iterable, Condition: condition}, nil
+}
+
 func (p *Parser) primary() (Expr, error) {
 	if p.match(scanner.TRUE) {
 		return LiteralExpr{Value: true}, nil
@@ -874,7 +2184,12 @@ func (p *Parser) primary() (Expr, error) {
 		return p.lambda()
 	}
 
+	if p.match(scanner.MATCH) {
+		return p.matchExpr()
+	}
+
 	if p.match(scanner.SUPER) {
+		keyword := p.peekBehind()
 		if _, err := p.consume(scanner.DOT, "Expected '.' after 'super'."); err != nil {
 			return nil, err
 		}
@@ -884,13 +2199,17 @@ func (p *Parser) primary() (Expr, error) {
 			return nil, err
 		}
 
-		return SuperExpr{Keyword: p.peekBehind(), Method: method}, nil
+		return SuperExpr{Keyword: keyword, Method: method}, nil
 	}
 
 	if p.match(scanner.LEFT_BRACKET) {
 		return p.array()
 	}
 
+	if p.check(scanner.LEFT_PAREN) && p.checkArrowLambda() {
+		return p.arrowLambda()
+	}
+
 	if p.match(scanner.LEFT_PAREN) {
 		expr, err := p.Expression()
 
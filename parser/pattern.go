@@ -0,0 +1,91 @@
+package parser
+
+import "glox/scanner"
+
+// Pattern is the left-hand side of a match case. Patterns are matched against a value by the
+// interpreter and declare the names they bind in the resolver.
+type Pattern interface {
+	pattern()
+}
+
+// LiteralPattern matches values equal to a literal.
+type LiteralPattern struct {
+	Token scanner.Token
+	Value any
+}
+
+// RangePattern matches numbers between two literals. The end is excluded for "..<" ranges.
+type RangePattern struct {
+	Token     scanner.Token
+	Start     any
+	End       any
+	Inclusive bool
+}
+
+// BindingPattern matches any value and binds it to a name, "_" matches without binding.
+type BindingPattern struct {
+	Name scanner.Token
+}
+
+// ValuePattern matches values equal to a dotted name such as "Color.Red".
+type ValuePattern struct {
+	Value Expr
+}
+
+// ArrayPattern matches arrays element by element, collecting the remaining elements into Rest.
+type ArrayPattern struct {
+	Bracket  scanner.Token
+	Elements []Pattern
+	Rest     *scanner.Token
+}
+
+// ClassPattern matches instances of a class, matching the fields named by the class
+// initializer's parameters against the argument patterns in order.
+type ClassPattern struct {
+	Class       Expr
+	Parenthesis scanner.Token
+	Arguments   []Pattern
+}
+
+// ObjectPattern matches instances, matching the listed properties against their patterns.
+type ObjectPattern struct {
+	Brace  scanner.Token
+	Fields []ObjectPatternField
+}
+
+type ObjectPatternField struct {
+	Name    scanner.Token
+	Pattern Pattern
+}
+
+type MatchCase struct {
+	Keyword scanner.Token
+	Pattern Pattern
+	Guard   Expr
+	Body    Expr
+	Block   []Stmt
+}
+
+func (LiteralPattern) pattern() {}
+func (RangePattern) pattern()   {}
+func (BindingPattern) pattern() {}
+func (ValuePattern) pattern()   {}
+func (ArrayPattern) pattern()   {}
+func (ClassPattern) pattern()   {}
+func (ObjectPattern) pattern()  {}
+
+// IsWildcard reports whether the pattern matches every value without binding it.
+func (b BindingPattern) IsWildcard() bool {
+	return b.Name.Lexeme == "_"
+}
+
+// SplitRest separates a trailing spread element of an array destructuring target.
+func SplitRest(elements []Expr) ([]Expr, Expr) {
+	if len(elements) != 0 {
+		if spread, ok := elements[len(elements)-1].(SpreadExpr); ok {
+			return elements[:len(elements)-1], spread.Expr
+		}
+	}
+
+	return elements, nil
+}
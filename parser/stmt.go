@@ -6,16 +6,23 @@ type VisitorStmt interface {
 	VisitExpressionStmt(ExpressionStmt) (any, error)
 	VisitPrintStmt(PrintStmt) (any, error)
 	VisitVarStmt(VarStmt) (any, error)
+	VisitDestructureStmt(DestructureStmt) (any, error)
 	VisitClassStmt(ClassStmt) (any, error)
 	VisitTraitStmt(TraitStmt) (any, error)
+	VisitEnumStmt(EnumStmt) (any, error)
 	VisitFunctionStmt(FunctionStmt) (any, error)
 	VisitBlockStmt(BlockStmt) (any, error)
 	VisitIfStmt(IfStmt) (any, error)
 	VisitWhileStmt(WhileStmt) (any, error)
+	VisitDoWhileStmt(DoWhileStmt) (any, error)
 	VisitForStmt(ForStmt) (any, error)
+	VisitSwitchStmt(SwitchStmt) (any, error)
 	VisitBreakStmt(BreakStmt) (any, error)
 	VisitContinueStmt(ContinueStmt) (any, error)
 	VisitReturnStmt(ReturnStmt) (any, error)
+	VisitDeferStmt(DeferStmt) (any, error)
+	VisitUsingStmt(UsingStmt) (any, error)
+	VisitAssertStmt(AssertStmt) (any, error)
 }
 
 type Stmt interface {
@@ -31,6 +38,7 @@ func (e ExpressionStmt) Accept(visitor VisitorStmt) (any, error) {
 }
 
 type PrintStmt struct {
+	Keyword    scanner.Token
 	Expression Expr
 }
 
@@ -40,19 +48,37 @@ func (p PrintStmt) Accept(visitor VisitorStmt) (any, error) {
 
 type VarStmt struct {
 	Name        scanner.Token
+	Type        *TypeAnnotation
 	Initializer Expr
+	Constant    bool
 }
 
 func (v VarStmt) Accept(visitor VisitorStmt) (any, error) {
 	return visitor.VisitVarStmt(v)
 }
 
+type DestructureStmt struct {
+	Keyword     scanner.Token
+	Pattern     Pattern
+	Initializer Expr
+}
+
+func (d DestructureStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitDestructureStmt(d)
+}
+
 type ClassStmt struct {
-	Name          scanner.Token
-	Superclass    VariableExpr
-	Traits        []VariableExpr
-	Methods       []FunctionStmt
-	StaticMethods []FunctionStmt
+	Name            scanner.Token
+	Abstract        bool
+	Superclass      VariableExpr
+	Traits          []TraitUse
+	AbstractMethods []FunctionStmt
+	Methods         []FunctionStmt
+	StaticMethods   []FunctionStmt
+	Setters         []FunctionStmt
+	StaticSetters   []FunctionStmt
+	Fields          []VarStmt
+	StaticFields    []VarStmt
 }
 
 func (c ClassStmt) Accept(visitor VisitorStmt) (any, error) {
@@ -61,17 +87,35 @@ func (c ClassStmt) Accept(visitor VisitorStmt) (any, error) {
 
 type TraitStmt struct {
 	Name          scanner.Token
+	Traits        []TraitUse
+	Required      []FunctionStmt
 	Methods       []FunctionStmt
 	StaticMethods []FunctionStmt
+	Setters       []FunctionStmt
+	StaticSetters []FunctionStmt
+	Fields        []VarStmt
+	StaticFields  []VarStmt
 }
 
 func (t TraitStmt) Accept(visitor VisitorStmt) (any, error) {
 	return visitor.VisitTraitStmt(t)
 }
 
+type EnumStmt struct {
+	Name          scanner.Token
+	Variants      []EnumVariant
+	Methods       []FunctionStmt
+	StaticMethods []FunctionStmt
+}
+
+func (e EnumStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitEnumStmt(e)
+}
+
 type FunctionStmt struct {
 	Name       scanner.Token
-	Parameters []scanner.Token
+	Parameters []Parameter
+	ReturnType *TypeAnnotation
 	Body       []Stmt
 }
 
@@ -88,6 +132,7 @@ func (b BlockStmt) Accept(visitor VisitorStmt) (any, error) {
 }
 
 type IfStmt struct {
+	Keyword    scanner.Token
 	Expression Expr
 	ThenBranch Stmt
 	ElseBranch Stmt
@@ -98,27 +143,54 @@ func (i IfStmt) Accept(visitor VisitorStmt) (any, error) {
 }
 
 type WhileStmt struct {
+	Keyword   scanner.Token
 	Condition Expr
 	Body      Stmt
+	Label     scanner.Token
 }
 
 func (w WhileStmt) Accept(visitor VisitorStmt) (any, error) {
 	return visitor.VisitWhileStmt(w)
 }
 
+type DoWhileStmt struct {
+	Keyword   scanner.Token
+	Body      Stmt
+	Condition Expr
+	Label     scanner.Token
+}
+
+func (d DoWhileStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitDoWhileStmt(d)
+}
+
 type ForStmt struct {
+	Keyword     scanner.Token
 	Initializer Stmt
 	Condition   Expr
 	Increment   Stmt
 	Body        Stmt
+	Label       scanner.Token
 }
 
 func (f ForStmt) Accept(visitor VisitorStmt) (any, error) {
 	return visitor.VisitForStmt(f)
 }
 
+type SwitchStmt struct {
+	Keyword scanner.Token
+	Subject Expr
+	Cases   []SwitchCase
+	Default []Stmt
+}
+
+func (s SwitchStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitSwitchStmt(s)
+}
+
 type BreakStmt struct {
 	Keyword scanner.Token
+	Label   scanner.Token
 }
 
 func (b BreakStmt) Accept(visitor VisitorStmt) (any, error) {
@@ -127,6 +199,7 @@ func (b BreakStmt) Accept(visitor VisitorStmt) (any, error) {
 
 type ContinueStmt struct {
 	Keyword scanner.Token
+	Label   scanner.Token
 }
 
 func (c ContinueStmt) Accept(visitor VisitorStmt) (any, error) {
@@ -141,3 +214,33 @@ type ReturnStmt struct {
 func (r ReturnStmt) Accept(visitor VisitorStmt) (any, error) {
 	return visitor.VisitReturnStmt(r)
 }
+
+type DeferStmt struct {
+	Keyword scanner.Token
+	Expr    Expr
+}
+
+func (d DeferStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitDeferStmt(d)
+}
+
+type UsingStmt struct {
+	Keyword     scanner.Token
+	Name        scanner.Token
+	Initializer Expr
+	Body        Stmt
+}
+
+func (u UsingStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitUsingStmt(u)
+}
+
+type AssertStmt struct {
+	Keyword   scanner.Token
+	Condition Expr
+	Message   Expr
+}
+
+func (a AssertStmt) Accept(visitor VisitorStmt) (any, error) {
+	return visitor.VisitAssertStmt(a)
+}
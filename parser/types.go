@@ -0,0 +1,55 @@
+package parser
+
+import "glox/scanner"
+
+// TypeAnnotation is an optional static type written after a name, e.g. "a: Number" or "b: String?".
+// Annotations are only used by the type checker and are ignored at runtime.
+type TypeAnnotation struct {
+	Name     scanner.Token
+	Nullable bool
+}
+
+// Parameter is a function parameter. Destructured parameters have a Pattern and use the
+// opening bracket or brace as their Name. Default is evaluated on every call that doesn't pass the
+// parameter, and a Rest parameter collects the remaining positional arguments into an array.
+type Parameter struct {
+	Name    scanner.Token
+	Type    *TypeAnnotation
+	Pattern Pattern
+	Default Expr
+	Rest    bool
+}
+
+// NamedArgument is an argument passed by parameter name, e.g. "b: 3" in "f(1, b: 3)".
+type NamedArgument struct {
+	Name  scanner.Token
+	Value Expr
+}
+
+// TraitUse is a trait listed after "<>" in a class or trait declaration. Aliases add a trait member
+// under a second name and Excluded leaves members out, e.g. "<> A { foo as aFoo, -bar }".
+type TraitUse struct {
+	Trait    VariableExpr
+	Aliases  []TraitAlias
+	Excluded []scanner.Token
+}
+
+// TraitAlias makes the trait member Member also available as Alias.
+type TraitAlias struct {
+	Member scanner.Token
+	Alias  scanner.Token
+}
+
+// EnumVariant is a variant of an enum declaration. Variants without Parameters are singleton
+// values, while the others construct a value carrying one field per parameter, e.g. "Ok(value)".
+type EnumVariant struct {
+	Name       scanner.Token
+	Parameters []Parameter
+}
+
+// SwitchCase is a case of a switch statement. Its Body runs when the subject equals any of Values.
+type SwitchCase struct {
+	Keyword scanner.Token
+	Values  []Expr
+	Body    []Stmt
+}
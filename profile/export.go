@@ -0,0 +1,167 @@
+package profile
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (p *Profiler) sortedStacks() []string {
+	stacks := make([]string, 0, len(p.stacks))
+	for stack := range p.stacks {
+		stacks = append(stacks, stack)
+	}
+	slices.Sort(stacks)
+
+	return stacks
+}
+
+// WriteFolded writes one line per call stack with the microseconds spent in its innermost
+// function, like "main;fib;fib 120", the input of flame graph tools.
+func WriteFolded(w io.Writer, p *Profiler) error {
+	var out strings.Builder
+	for _, stack := range p.sortedStacks() {
+		fmt.Fprintf(&out, "%s %d\n", stack, p.stacks[stack].flat.Microseconds())
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// WritePprof writes the profile in the gzipped protocol buffer format of pprof, with one sample
+// per call stack holding its number of calls and the nanoseconds spent in its innermost function.
+func WritePprof(w io.Writer, p *Profiler) error {
+	table := newStringTable()
+	profile := &protoBuffer{}
+
+	for _, sampleType := range [][2]string{{"calls", "count"}, {"time", "nanoseconds"}} {
+		profile.message(1, func(valueType *protoBuffer) {
+			valueType.int64(1, table.index(sampleType[0]))
+			valueType.int64(2, table.index(sampleType[1]))
+		})
+	}
+
+	// Functions and locations share their ids, as each function has a single location.
+	functions := p.sortedFunctions(func(*functionStats) time.Duration { return 0 })
+	ids := make(map[*functionStats]uint64)
+	for _, function := range functions {
+		ids[function] = uint64(len(ids) + 1)
+	}
+
+	for _, stack := range p.sortedStacks() {
+		stats := p.stacks[stack]
+		profile.message(2, func(sample *protoBuffer) {
+			locations := make([]uint64, 0, len(stats.functions))
+			for idx := len(stats.functions) - 1; idx >= 0; idx-- {
+				locations = append(locations, ids[stats.functions[idx]])
+			}
+			sample.packedUint64(1, locations)
+			sample.packedUint64(2, []uint64{uint64(stats.calls), uint64(stats.flat.Nanoseconds())})
+		})
+	}
+
+	for _, function := range functions {
+		profile.message(4, func(location *protoBuffer) {
+			location.uint64(1, ids[function])
+			location.message(4, func(line *protoBuffer) {
+				line.uint64(1, ids[function])
+				line.int64(2, int64(function.line))
+			})
+		})
+	}
+
+	for _, function := range functions {
+		profile.message(5, func(entry *protoBuffer) {
+			entry.uint64(1, ids[function])
+			entry.int64(2, table.index(function.name))
+			entry.int64(3, table.index(function.name))
+			entry.int64(4, table.index(p.Path))
+			entry.int64(5, int64(function.line))
+		})
+	}
+
+	timeIndex := table.index("time")
+	for _, value := range table.values {
+		profile.bytes(6, []byte(value))
+	}
+	profile.int64(9, p.start.UnixNano())
+	profile.int64(10, p.duration.Nanoseconds())
+	profile.int64(14, timeIndex)
+
+	compressed := gzip.NewWriter(w)
+	if _, err := compressed.Write(profile.data); err != nil {
+		return err
+	}
+
+	return compressed.Close()
+}
+
+// stringTable holds the strings of a pprof profile, which refers to them by index. The first
+// one is always empty.
+type stringTable struct {
+	values  []string
+	indices map[string]int64
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{values: []string{""}, indices: map[string]int64{"": 0}}
+}
+
+func (t *stringTable) index(value string) int64 {
+	if idx, ok := t.indices[value]; ok {
+		return idx
+	}
+
+	t.indices[value] = int64(len(t.values))
+	t.values = append(t.values, value)
+	return t.indices[value]
+}
+
+// protoBuffer encodes the few protocol buffer wire types a pprof profile needs.
+type protoBuffer struct {
+	data []byte
+}
+
+func (b *protoBuffer) varint(value uint64) {
+	for value >= 0x80 {
+		b.data = append(b.data, byte(value)|0x80)
+		value >>= 7
+	}
+	b.data = append(b.data, byte(value))
+}
+
+func (b *protoBuffer) tag(field int, wireType uint64) {
+	b.varint(uint64(field)<<3 | wireType)
+}
+
+func (b *protoBuffer) uint64(field int, value uint64) {
+	b.tag(field, 0)
+	b.varint(value)
+}
+
+func (b *protoBuffer) int64(field int, value int64) {
+	b.uint64(field, uint64(value))
+}
+
+func (b *protoBuffer) bytes(field int, value []byte) {
+	b.tag(field, 2)
+	b.varint(uint64(len(value)))
+	b.data = append(b.data, value...)
+}
+
+func (b *protoBuffer) packedUint64(field int, values []uint64) {
+	packed := &protoBuffer{}
+	for _, value := range values {
+		packed.varint(value)
+	}
+	b.bytes(field, packed.data)
+}
+
+func (b *protoBuffer) message(field int, encode func(*protoBuffer)) {
+	nested := &protoBuffer{}
+	encode(nested)
+	b.bytes(field, nested.data)
+}
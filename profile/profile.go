@@ -0,0 +1,177 @@
+// Package profile measures where a program spends its time, by function and by line, and reports
+// it as a table, as folded stacks for flame graphs, or in the pprof format.
+package profile
+
+import (
+	"fmt"
+	"glox/parser"
+	"slices"
+	"strings"
+	"time"
+)
+
+// mainFunction stands for the top-level code of the program.
+const mainFunction = "main"
+
+// Profiler implements interpreter.Tracer. It traces every call and statement rather than sampling:
+// every event of the interpreter closes the time slice since the previous one, which is spent by
+// the function on top of the stack and its current line.
+type Profiler struct {
+	Path  string
+	lines []string
+	start time.Time
+	last  time.Time
+	// duration is set once the profiler stops.
+	duration  time.Duration
+	stack     []*frame
+	functions map[string]*functionStats
+	lineStats map[int32]*lineStats
+	stacks    map[string]*stackStats
+}
+
+// frame is a running function. line is the line of the statement it runs, or 0 before its first
+// statement. stack is the names of the frames up to it, joined by ';'.
+type frame struct {
+	function *functionStats
+	line     int32
+	start    time.Time
+	stack    string
+}
+
+type functionStats struct {
+	name  string
+	line  int32
+	calls int
+	// flat is the time spent in the function itself, cumulative also counts the functions it calls.
+	flat       time.Duration
+	cumulative time.Duration
+}
+
+type lineStats struct {
+	hits int
+	flat time.Duration
+}
+
+type stackStats struct {
+	// functions are the functions of the stack, from the outermost one.
+	functions []*functionStats
+	calls     int
+	flat      time.Duration
+}
+
+// New creates a profiler for the program at path and starts measuring.
+func New(path string, source string) *Profiler {
+	now := time.Now()
+	profiler := &Profiler{
+		Path:      path,
+		lines:     strings.Split(source, "\n"),
+		start:     now,
+		last:      now,
+		stack:     make([]*frame, 0),
+		functions: make(map[string]*functionStats),
+		lineStats: make(map[int32]*lineStats),
+		stacks:    make(map[string]*stackStats),
+	}
+	profiler.push(mainFunction, 0, now)
+
+	return profiler
+}
+
+// Stop ends the measure. Functions that didn't return, because of a runtime error, are counted
+// as if they returned now.
+func (p *Profiler) Stop() {
+	p.tick()
+	for len(p.stack) > 0 {
+		p.pop()
+	}
+	p.duration = p.last.Sub(p.start)
+}
+
+func (p *Profiler) Statement(stmt parser.Stmt) {
+	p.tick()
+
+	line := parser.StmtLine(stmt)
+	if line == 0 {
+		return
+	}
+
+	p.top().line = line
+	if _, ok := p.lineStats[line]; !ok {
+		p.lineStats[line] = &lineStats{}
+	}
+	p.lineStats[line].hits++
+}
+
+// Branch is ignored, as the time of a branch is spent by the statements around it.
+func (p *Profiler) Branch(_ any, _ bool) {
+}
+
+func (p *Profiler) Call(name string, line int32) {
+	p.tick()
+	p.push(name, line, p.last)
+}
+
+func (p *Profiler) Return() {
+	p.tick()
+	p.pop()
+}
+
+func (p *Profiler) push(name string, line int32, now time.Time) {
+	// Functions are told apart by their line too, as all lambdas are named "lambda".
+	key := fmt.Sprintf("%s:%d", name, line)
+	function, ok := p.functions[key]
+	if !ok {
+		function = &functionStats{name: name, line: line}
+		p.functions[key] = function
+	}
+	function.calls++
+
+	stack, functions := name, []*functionStats{function}
+	if len(p.stack) > 0 {
+		stack = p.top().stack + ";" + name
+		functions = append(slices.Clone(p.stacks[p.top().stack].functions), function)
+	}
+	if _, ok := p.stacks[stack]; !ok {
+		p.stacks[stack] = &stackStats{functions: functions}
+	}
+	p.stacks[stack].calls++
+
+	// The call is set up before the first statement of the function runs, so that time isn't
+	// spent on the line declaring the function.
+	p.stack = append(p.stack, &frame{function: function, start: now, stack: stack})
+}
+
+func (p *Profiler) pop() {
+	popped := p.top()
+	p.stack = p.stack[:len(p.stack)-1]
+
+	// A recursive call is already counted by the outermost call of the function.
+	for _, frame := range p.stack {
+		if frame.function == popped.function {
+			return
+		}
+	}
+	popped.function.cumulative += p.last.Sub(popped.start)
+}
+
+func (p *Profiler) top() *frame {
+	return p.stack[len(p.stack)-1]
+}
+
+// tick gives the time since the previous event to the function on top of the stack.
+func (p *Profiler) tick() {
+	now := time.Now()
+	elapsed := now.Sub(p.last)
+	p.last = now
+
+	if len(p.stack) == 0 {
+		return
+	}
+
+	top := p.top()
+	top.function.flat += elapsed
+	p.stacks[top.stack].flat += elapsed
+	if line, ok := p.lineStats[top.line]; ok {
+		line.flat += elapsed
+	}
+}
@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// maxReportLines caps the number of lines in the line report, which are sorted by time.
+const maxReportLines = 20
+
+// sortedFunctions returns the functions by decreasing time, as given by value, then by name.
+func (p *Profiler) sortedFunctions(value func(*functionStats) time.Duration) []*functionStats {
+	functions := make([]*functionStats, 0, len(p.functions))
+	for _, function := range p.functions {
+		functions = append(functions, function)
+	}
+
+	slices.SortFunc(functions, func(a, b *functionStats) int {
+		if order := cmp.Compare(value(b), value(a)); order != 0 {
+			return order
+		}
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.line, b.line))
+	})
+
+	return functions
+}
+
+func (p *Profiler) percent(duration time.Duration) string {
+	if p.duration == 0 {
+		return "0.0%"
+	}
+
+	return fmt.Sprintf("%.1f%%", 100*float64(duration)/float64(p.duration))
+}
+
+func (f *functionStats) String() string {
+	if f.name == mainFunction {
+		return f.name
+	}
+
+	return fmt.Sprintf("%s (line %d)", f.name, f.line)
+}
+
+// WriteReport writes the functions sorted by the time spent in themselves (flat) and by the time
+// spent in them and the functions they call (cumulative), followed by the lines that took the most
+// time.
+func WriteReport(w io.Writer, p *Profiler) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "Total: %s\n", formatDuration(p.duration))
+
+	reports := []struct {
+		title string
+		value func(*functionStats) time.Duration
+	}{
+		{"Flat", func(function *functionStats) time.Duration { return function.flat }},
+		{"Cumulative", func(function *functionStats) time.Duration { return function.cumulative }},
+	}
+
+	for _, report := range reports {
+		fmt.Fprintf(table, "\n%s profile:\n", report.title)
+		fmt.Fprintln(table, "flat\tflat%\tsum%\tcum\tcum%\tcalls\t\tfunction")
+
+		// Like in pprof, sum% adds up the flat time of the functions listed so far.
+		var sum time.Duration
+		for _, function := range p.sortedFunctions(report.value) {
+			sum += function.flat
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%d\t\t%s\n", formatDuration(function.flat), p.percent(function.flat), p.percent(sum), formatDuration(function.cumulative), p.percent(function.cumulative), function.calls, function)
+		}
+	}
+
+	fmt.Fprintln(table, "\nLines:")
+	fmt.Fprintln(table, "line\thits\tflat\tflat%\t\tsource")
+	for _, line := range p.sortedLines() {
+		stats := p.lineStats[line]
+		fmt.Fprintf(table, "%d\t%d\t%s\t%s\t\t%s\n", line, stats.hits, formatDuration(stats.flat), p.percent(stats.flat), p.source(line))
+	}
+
+	return table.Flush()
+}
+
+// sortedLines returns the lines that took the most time, up to maxReportLines of them.
+func (p *Profiler) sortedLines() []int32 {
+	lines := make([]int32, 0, len(p.lineStats))
+	for line := range p.lineStats {
+		lines = append(lines, line)
+	}
+
+	slices.SortFunc(lines, func(a, b int32) int {
+		return cmp.Or(cmp.Compare(p.lineStats[b].flat, p.lineStats[a].flat), cmp.Compare(a, b))
+	})
+
+	return lines[:min(len(lines), maxReportLines)]
+}
+
+func (p *Profiler) source(line int32) string {
+	if int(line) > len(p.lines) {
+		return ""
+	}
+
+	return strings.TrimSpace(p.lines[line-1])
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Microsecond).String()
+}
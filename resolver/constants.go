@@ -14,4 +14,6 @@ const (
 	classTypeNone     = "NONE"
 	classTypeClass    = "CLASS"
 	classTypeSubclass = "SUBCLASS"
+	classTypeTrait    = "TRAIT"
+	classTypeEnum     = "ENUM"
 )
@@ -6,11 +6,13 @@ import (
 	"glox/parser"
 	"glox/scanner"
 	"reflect"
+	"slices"
 )
 
 type variable struct {
-	token scanner.Token
-	state string
+	token    scanner.Token
+	state    string
+	constant bool
 }
 
 type Resolver struct {
@@ -20,6 +22,9 @@ type Resolver struct {
 	currentFunction string
 	currentClass    string
 	loopLevel       int
+	switchLevel     int
+	// labels holds the label of every enclosing loop, or "" for loops without one.
+	labels []string
 }
 
 func New(interpreter *interpreter.Interpreter) *Resolver {
@@ -53,7 +58,9 @@ func (r *Resolver) declare(name scanner.Token) error {
 
 	scope := *r.peekScope()
 
-	if _, ok := scope[name.Lexeme]; ok {
+	if existing, ok := scope[name.Lexeme]; ok && existing.constant {
+		return r.newError(name, fmt.Sprintf("Can't redeclare constant '%s'.", name.Lexeme))
+	} else if ok {
 		return r.newError(name, fmt.Sprintf("Redeclared '%s' variable in this scope.", name.Lexeme))
 	}
 
@@ -89,16 +96,31 @@ func (r *Resolver) peekScope() *map[string]*variable {
 	return &r.scopes[len(r.scopes)-1]
 }
 
-func (r *Resolver) beginLoop() int {
+func (r *Resolver) beginLoop(label scanner.Token) error {
+	if label.Lexeme != "" && slices.Contains(r.labels, label.Lexeme) {
+		return r.newError(label, fmt.Sprintf("Label '%s' is already used by an enclosing loop.", label.Lexeme))
+	}
+
 	r.loopLevel += 1
-	return r.loopLevel
+	r.labels = append(r.labels, label.Lexeme)
+	return nil
 }
 
 func (r *Resolver) endLoop() int {
 	r.loopLevel -= 1
+	r.labels = r.labels[:len(r.labels)-1]
 	return r.loopLevel
 }
 
+// resolveLabel checks that a labeled break or continue is inside a loop with that label.
+func (r *Resolver) resolveLabel(label scanner.Token) (any, error) {
+	if !slices.Contains(r.labels, label.Lexeme) {
+		return nil, r.newError(label, fmt.Sprintf("No enclosing loop is labeled '%s'.", label.Lexeme))
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) insideLoop() bool {
 	return r.loopLevel > 0
 }
@@ -122,7 +144,7 @@ func (r *Resolver) resolveStmts(stmt []parser.Stmt) (any, error) {
 }
 
 func (r *Resolver) resolveFunctions(function any, functionType string) (any, error) {
-	var parameters []scanner.Token
+	var parameters []parser.Parameter
 	var body []parser.Stmt
 
 	if stmt, ok := function.(parser.FunctionStmt); ok {
@@ -134,21 +156,38 @@ func (r *Resolver) resolveFunctions(function any, functionType string) (any, err
 	}
 
 	previousFunction := r.currentFunction
+	// Loops and switches outside of the function can't be left from inside it.
+	loopLevel, switchLevel, labels := r.loopLevel, r.switchLevel, r.labels
 
 	r.currentFunction = functionType
+	r.loopLevel, r.switchLevel, r.labels = 0, 0, nil
 	r.beginScope()
 
 	defer func() {
 		r.currentFunction = previousFunction
+		r.loopLevel, r.switchLevel, r.labels = loopLevel, switchLevel, labels
 		r.endScope()
 	}()
 
 	if parameters != nil {
 		for _, parameter := range parameters {
-			if err := r.declare(parameter); err != nil {
+			if parameter.Default != nil {
+				if _, err := r.resolveExpr(parameter.Default); err != nil {
+					return nil, err
+				}
+			}
+
+			if parameter.Pattern != nil {
+				if _, err := r.resolvePattern(parameter.Pattern); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			if err := r.declare(parameter.Name); err != nil {
 				return nil, err
 			}
-			r.define(parameter)
+			r.define(parameter.Name)
 		}
 	}
 
@@ -180,6 +219,98 @@ func (r *Resolver) VisitArrayExpr(expr parser.ArrayExpr) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitRangeExpr(expr parser.RangeExpr) (any, error) {
+	for _, operand := range []parser.Expr{expr.Start, expr.End, expr.Step} {
+		if operand == nil {
+			continue
+		}
+
+		if _, err := r.resolveExpr(operand); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// VisitComprehensionExpr resolves the iterable outside of the comprehension, and the condition
+// and element in a scope holding the comprehension variable.
+func (r *Resolver) VisitComprehensionExpr(expr parser.ComprehensionExpr) (any, error) {
+	if _, err := r.resolveExpr(expr.Iterable); err != nil {
+		return nil, err
+	}
+
+	r.beginScope()
+	defer r.endScope()
+
+	if err := r.declare(expr.Name); err != nil {
+		return nil, err
+	}
+	r.define(expr.Name)
+
+	if expr.Condition != nil {
+		if _, err := r.resolveExpr(expr.Condition); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.resolveExpr(expr.Element)
+}
+
+func (r *Resolver) VisitSpreadExpr(expr parser.SpreadExpr) (any, error) {
+	return r.resolveExpr(expr.Expr)
+}
+
+func (r *Resolver) VisitDestructureAssignmentExpr(expr parser.DestructureAssignmentExpr) (any, error) {
+	if _, err := r.resolveExpr(expr.Value); err != nil {
+		return nil, err
+	}
+
+	if expr.Rest != nil {
+		if _, err := r.resolveTarget(expr.Rest); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.resolveTargets(expr.Targets)
+}
+
+func (r *Resolver) resolveTargets(targets []parser.Expr) (any, error) {
+	for _, target := range targets {
+		if _, err := r.resolveTarget(target); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveTarget resolves a single destination of a destructuring assignment. Variables are
+// written rather than read, so they don't count as used.
+func (r *Resolver) resolveTarget(target parser.Expr) (any, error) {
+	switch target := target.(type) {
+	case parser.VariableExpr:
+		return r.resolveAssignment(target, target.Name)
+	case parser.GetExpr:
+		return r.resolveMember(target.Object, target.Name)
+	case parser.ArrayGetExpr:
+		if _, err := r.resolveExpr(target.Array); err != nil {
+			return nil, err
+		}
+		return r.resolveExpr(target.Index)
+	case parser.ArrayExpr:
+		targets, rest := parser.SplitRest(target.Elements)
+		if rest != nil {
+			if _, err := r.resolveTarget(rest); err != nil {
+				return nil, err
+			}
+		}
+		return r.resolveTargets(targets)
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitTernaryExpr(expr parser.TernaryExpr) (any, error) {
 	if _, err := r.resolveExpr(expr.Condition); err != nil {
 		return nil, err
@@ -197,7 +328,22 @@ func (r *Resolver) VisitAssignmentExpr(expr parser.AssignmentExpr) (any, error)
 		return nil, err
 	}
 
-	return r.resolveLocal(expr, expr.Name, false)
+	return r.resolveAssignment(expr, expr.Name)
+}
+
+// resolveAssignment resolves a variable that is assigned to, rejecting constants declared in
+// an enclosing local scope. Global constants are only known at runtime.
+func (r *Resolver) resolveAssignment(expr parser.Expr, name scanner.Token) (any, error) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if variable, ok := r.scopes[i][name.Lexeme]; ok {
+			if variable.constant {
+				return nil, r.newError(name, fmt.Sprintf("Can't reassign constant '%s'.", name.Lexeme))
+			}
+			break
+		}
+	}
+
+	return r.resolveLocal(expr, name, false)
 }
 
 func (r *Resolver) VisitLogicalExpr(expr parser.LogicalExpr) (any, error) {
@@ -209,13 +355,29 @@ func (r *Resolver) VisitLogicalExpr(expr parser.LogicalExpr) (any, error) {
 }
 
 func (r *Resolver) VisitSetExpr(expr parser.SetExpr) (any, error) {
-	if _, err := r.resolveExpr(expr.Object); err != nil {
+	if _, err := r.resolveMember(expr.Object, expr.Name); err != nil {
 		return nil, err
 	}
 
 	return r.resolveExpr(expr.Value)
 }
 
+// resolveMember resolves the object of a property access. Private members can only be reached
+// through 'this' inside of a class.
+func (r *Resolver) resolveMember(object parser.Expr, name scanner.Token) (any, error) {
+	if name.Type == scanner.PRIVATE_IDENTIFIER {
+		if r.currentClass == classTypeNone {
+			return nil, r.newError(name, fmt.Sprintf("Can't access private member '%s' outside of a class.", name.Lexeme))
+		}
+
+		if _, ok := object.(parser.ThisExpr); !ok {
+			return nil, r.newError(name, fmt.Sprintf("Private member '%s' can only be accessed through 'this'.", name.Lexeme))
+		}
+	}
+
+	return r.resolveExpr(object)
+}
+
 func (r *Resolver) VisitArraySetExpr(expr parser.ArraySetExpr) (any, error) {
 	if _, err := r.resolveExpr(expr.Index); err != nil {
 		return nil, err
@@ -231,6 +393,12 @@ func (r *Resolver) VisitArraySetExpr(expr parser.ArraySetExpr) (any, error) {
 func (r *Resolver) VisitSuperExpr(expr parser.SuperExpr) (any, error) {
 	if r.currentClass == classTypeNone {
 		return nil, r.newError(expr.Keyword, "Can't use 'super' outside of a class.")
+	} else if r.currentClass == classTypeEnum {
+		return nil, r.newError(expr.Keyword, "Can't use 'super' in an enum.")
+	} else if r.currentClass == classTypeTrait {
+		if !r.inTraitMethod() {
+			return nil, r.newError(expr.Keyword, "Can't use 'super' outside of the instance methods of a trait.")
+		}
 	} else if r.currentClass != classTypeSubclass {
 		return nil, r.newError(expr.Keyword, "Can't use 'super' in a class with no superclass.")
 	}
@@ -238,6 +406,19 @@ func (r *Resolver) VisitSuperExpr(expr parser.SuperExpr) (any, error) {
 	return r.resolveLocal(expr, expr.Keyword, true)
 }
 
+// inTraitMethod reports whether 'super' is available in the trait being resolved, which is only
+// the case in its instance methods and setters.
+func (r *Resolver) inTraitMethod() bool {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if _, ok := r.scopes[i]["this"]; ok {
+			_, ok := r.scopes[i]["super"]
+			return ok
+		}
+	}
+
+	return false
+}
+
 func (r *Resolver) VisitBinaryExpr(expr parser.BinaryExpr) (any, error) {
 	if _, err := r.resolveExpr(expr.Left); err != nil {
 		return nil, err
@@ -259,7 +440,7 @@ func (r *Resolver) VisitUnaryExpr(expr parser.UnaryExpr) (any, error) {
 }
 
 func (r *Resolver) VisitGetExpr(expr parser.GetExpr) (any, error) {
-	return r.resolveExpr(expr.Object)
+	return r.resolveMember(expr.Object, expr.Name)
 }
 
 func (r *Resolver) VisitArrayGetExpr(expr parser.ArrayGetExpr) (any, error) {
@@ -270,6 +451,10 @@ func (r *Resolver) VisitArrayGetExpr(expr parser.ArrayGetExpr) (any, error) {
 	return r.resolveExpr(expr.Array)
 }
 
+func (r *Resolver) VisitOptionalChainExpr(expr parser.OptionalChainExpr) (any, error) {
+	return r.resolveExpr(expr.Expr)
+}
+
 func (r *Resolver) VisitCallExpr(expr parser.CallExpr) (any, error) {
 	if _, err := r.resolveExpr(expr.Callee); err != nil {
 		return nil, err
@@ -281,6 +466,12 @@ func (r *Resolver) VisitCallExpr(expr parser.CallExpr) (any, error) {
 		}
 	}
 
+	for _, argument := range expr.NamedArguments {
+		if _, err := r.resolveExpr(argument.Value); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
 
@@ -313,6 +504,106 @@ func (r *Resolver) VisitVariableExpr(expr parser.VariableExpr) (any, error) {
 	return r.resolveLocal(expr, expr.Name, true)
 }
 
+func (r *Resolver) VisitMatchExpr(expr parser.MatchExpr) (any, error) {
+	if _, err := r.resolveExpr(expr.Subject); err != nil {
+		return nil, err
+	}
+
+	exhausted := false
+	seenLiterals := make(map[string]bool)
+
+	for _, matchCase := range expr.Cases {
+		if exhausted {
+			r.newWarning(matchCase.Keyword, "Unreachable match case.")
+		}
+
+		if literal, ok := matchCase.Pattern.(parser.LiteralPattern); ok && matchCase.Guard == nil {
+			key := fmt.Sprintf("%T:%v", literal.Value, literal.Value)
+			if seenLiterals[key] && !exhausted {
+				r.newWarning(matchCase.Keyword, "Unreachable match case.")
+			}
+			seenLiterals[key] = true
+		}
+
+		if _, ok := matchCase.Pattern.(parser.BindingPattern); ok && matchCase.Guard == nil {
+			exhausted = true
+		}
+
+		if _, err := r.resolveMatchCase(matchCase); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) resolveMatchCase(matchCase parser.MatchCase) (any, error) {
+	r.beginScope()
+	defer r.endScope()
+
+	if _, err := r.resolvePattern(matchCase.Pattern); err != nil {
+		return nil, err
+	}
+
+	if matchCase.Guard != nil {
+		if _, err := r.resolveExpr(matchCase.Guard); err != nil {
+			return nil, err
+		}
+	}
+
+	if matchCase.Block != nil {
+		return r.VisitBlockStmt(parser.BlockStmt{Declarations: matchCase.Block})
+	}
+
+	return r.resolveExpr(matchCase.Body)
+}
+
+func (r *Resolver) resolvePattern(pattern parser.Pattern) (any, error) {
+	switch pattern := pattern.(type) {
+	case parser.BindingPattern:
+		if pattern.IsWildcard() {
+			return nil, nil
+		}
+		if err := r.declare(pattern.Name); err != nil {
+			return nil, err
+		}
+		r.define(pattern.Name)
+	case parser.ValuePattern:
+		return r.resolveExpr(pattern.Value)
+	case parser.ArrayPattern:
+		for _, element := range pattern.Elements {
+			if _, err := r.resolvePattern(element); err != nil {
+				return nil, err
+			}
+		}
+
+		if pattern.Rest != nil {
+			if err := r.declare(*pattern.Rest); err != nil {
+				return nil, err
+			}
+			r.define(*pattern.Rest)
+		}
+	case parser.ClassPattern:
+		if _, err := r.resolveExpr(pattern.Class); err != nil {
+			return nil, err
+		}
+
+		for _, argument := range pattern.Arguments {
+			if _, err := r.resolvePattern(argument); err != nil {
+				return nil, err
+			}
+		}
+	case parser.ObjectPattern:
+		for _, field := range pattern.Fields {
+			if _, err := r.resolvePattern(field.Pattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitExpressionStmt(stmt parser.ExpressionStmt) (any, error) {
 	return r.resolveExpr(stmt.Expression)
 }
@@ -332,9 +623,21 @@ func (r *Resolver) VisitVarStmt(stmt parser.VarStmt) (any, error) {
 	}
 	r.define(stmt.Name)
 
+	if stmt.Constant && len(r.scopes) > 0 {
+		(*r.peekScope())[stmt.Name.Lexeme].constant = true
+	}
+
 	return nil, nil
 }
 
+func (r *Resolver) VisitDestructureStmt(stmt parser.DestructureStmt) (any, error) {
+	if _, err := r.resolveExpr(stmt.Initializer); err != nil {
+		return nil, err
+	}
+
+	return r.resolvePattern(stmt.Pattern)
+}
+
 func (r *Resolver) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 	currentClass := r.currentClass
 	r.currentClass = classTypeClass
@@ -366,15 +669,25 @@ func (r *Resolver) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 		r.currentClass = currentClass
 	}()
 
-	for _, trait := range stmt.Traits {
-		if _, err := r.resolveExpr(trait); err != nil {
-			return nil, err
-		}
+	if _, err := r.resolveTraitUses(stmt.Traits); err != nil {
+		return nil, err
 	}
 
 	lastScope := *r.peekScope()
 	lastScope["this"] = &variable{state: variableStateRead}
 
+	if _, err := r.resolveFields(stmt.Fields, stmt.StaticFields, stmt.Methods, stmt.StaticMethods); err != nil {
+		return nil, err
+	}
+
+	for _, abstract := range stmt.AbstractMethods {
+		for _, method := range stmt.Methods {
+			if method.Name.Lexeme == abstract.Name.Lexeme {
+				return nil, r.newError(method.Name, fmt.Sprintf("'%s' is declared both as an abstract method and as a method.", method.Name.Lexeme))
+			}
+		}
+	}
+
 	for _, method := range stmt.Methods {
 		funcType := functionTypeMethod
 		if method.Name.Lexeme == "init" {
@@ -387,11 +700,19 @@ func (r *Resolver) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 	}
 
 	for _, method := range stmt.StaticMethods {
+		if method.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			return nil, r.newError(method.Name, fmt.Sprintf("Static method '%s' can't be private.", method.Name.Lexeme))
+		}
+
 		if _, err := r.resolveFunctions(method, functionTypeStaticMethod); err != nil {
 			return nil, err
 		}
 	}
 
+	if _, err := r.resolveSetters(stmt.Setters, stmt.StaticSetters); err != nil {
+		return nil, err
+	}
+
 	if superclassExists {
 		r.endScope()
 	}
@@ -401,13 +722,17 @@ func (r *Resolver) VisitClassStmt(stmt parser.ClassStmt) (any, error) {
 
 func (r *Resolver) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
 	currentClass := r.currentClass
-	r.currentClass = classTypeClass
+	r.currentClass = classTypeTrait
 
 	if err := r.declare(stmt.Name); err != nil {
 		return nil, err
 	}
 	r.define(stmt.Name)
 
+	if _, err := r.resolveTraitUses(stmt.Traits); err != nil {
+		return nil, err
+	}
+
 	r.beginScope()
 	defer func() {
 		r.endScope()
@@ -417,6 +742,20 @@ func (r *Resolver) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
 	lastScope := *r.peekScope()
 	lastScope["this"] = &variable{state: variableStateRead}
 
+	if _, err := r.resolveFields(stmt.Fields, stmt.StaticFields, stmt.Methods, stmt.StaticMethods); err != nil {
+		return nil, err
+	}
+
+	for _, method := range stmt.Required {
+		if method.Name.Lexeme == "init" {
+			return nil, r.newError(method.Name, "Traits can't include init() method.")
+		}
+	}
+
+	// 'super' continues the lookup after the trait, which needs the instance, so it's only
+	// available to instance methods and setters.
+	lastScope["super"] = &variable{state: variableStateRead}
+
 	for _, method := range stmt.Methods {
 		if method.Name.Lexeme == "init" {
 			return nil, r.newError(method.Name, "Traits can't include init() method.")
@@ -427,6 +766,71 @@ func (r *Resolver) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
 		}
 	}
 
+	if _, err := r.resolveSetters(stmt.Setters, nil); err != nil {
+		return nil, err
+	}
+
+	delete(lastScope, "super")
+
+	for _, method := range stmt.StaticMethods {
+		if method.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			return nil, r.newError(method.Name, fmt.Sprintf("Static method '%s' can't be private.", method.Name.Lexeme))
+		}
+
+		if _, err := r.resolveFunctions(method, functionTypeStaticMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.resolveSetters(nil, stmt.StaticSetters)
+}
+
+func (r *Resolver) VisitEnumStmt(stmt parser.EnumStmt) (any, error) {
+	if err := r.declare(stmt.Name); err != nil {
+		return nil, err
+	}
+	r.define(stmt.Name)
+
+	declared := make(map[string]bool)
+	for _, variant := range stmt.Variants {
+		if declared[variant.Name.Lexeme] {
+			return nil, r.newError(variant.Name, fmt.Sprintf("Variant '%s' is already declared in enum '%s'.", variant.Name.Lexeme, stmt.Name.Lexeme))
+		}
+		declared[variant.Name.Lexeme] = true
+
+		if _, err := r.resolveVariantParameters(variant.Parameters); err != nil {
+			return nil, err
+		}
+	}
+
+	currentClass := r.currentClass
+	r.currentClass = classTypeEnum
+
+	r.beginScope()
+	defer func() {
+		r.endScope()
+		r.currentClass = currentClass
+	}()
+
+	lastScope := *r.peekScope()
+	lastScope["this"] = &variable{state: variableStateRead}
+
+	for _, method := range slices.Concat(stmt.Methods, stmt.StaticMethods) {
+		if method.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			return nil, r.newError(method.Name, fmt.Sprintf("Enum method '%s' can't be private.", method.Name.Lexeme))
+		}
+	}
+
+	for _, method := range stmt.Methods {
+		if method.Name.Lexeme == "init" {
+			return nil, r.newError(method.Name, "Enums can't include init() method.")
+		}
+
+		if _, err := r.resolveFunctions(method, functionTypeMethod); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, method := range stmt.StaticMethods {
 		if _, err := r.resolveFunctions(method, functionTypeStaticMethod); err != nil {
 			return nil, err
@@ -436,6 +840,121 @@ func (r *Resolver) VisitTraitStmt(stmt parser.TraitStmt) (any, error) {
 	return nil, nil
 }
 
+// resolveVariantParameters resolves the parameters of an enum variant in their own scope, so
+// defaults can refer to earlier parameters. They are stored in the enum value rather than read,
+// so they are never reported as unused.
+func (r *Resolver) resolveVariantParameters(parameters []parser.Parameter) (any, error) {
+	r.beginScope()
+	defer r.endScope()
+
+	for _, parameter := range parameters {
+		if parameter.Default != nil {
+			if _, err := r.resolveExpr(parameter.Default); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := r.declare(parameter.Name); err != nil {
+			return nil, err
+		}
+		r.define(parameter.Name)
+		(*r.peekScope())[parameter.Name.Lexeme].state = variableStateRead
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) resolveTraitUses(uses []parser.TraitUse) (any, error) {
+	for _, use := range uses {
+		if _, err := r.resolveExpr(use.Trait); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveFields resolves the initializers of declared fields in the class scope, where 'this' is
+// available to instance fields, and rejects fields declared twice or named like a method.
+func (r *Resolver) resolveFields(fields []parser.VarStmt, staticFields []parser.VarStmt, methods []parser.FunctionStmt, staticMethods []parser.FunctionStmt) (any, error) {
+	if err := r.checkFieldNames(fields, methods); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkFieldNames(staticFields, staticMethods); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if field.Initializer != nil {
+			if _, err := r.resolveExpr(field.Initializer); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	previousFunction := r.currentFunction
+	r.currentFunction = functionTypeStaticMethod
+	defer func() {
+		r.currentFunction = previousFunction
+	}()
+
+	for _, field := range staticFields {
+		if field.Name.Type == scanner.PRIVATE_IDENTIFIER {
+			return nil, r.newError(field.Name, fmt.Sprintf("Static field '%s' can't be private.", field.Name.Lexeme))
+		}
+
+		if field.Initializer != nil {
+			if _, err := r.resolveExpr(field.Initializer); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) checkFieldNames(fields []parser.VarStmt, methods []parser.FunctionStmt) error {
+	declared := make(map[string]bool)
+
+	for _, field := range fields {
+		if declared[field.Name.Lexeme] {
+			return r.newError(field.Name, fmt.Sprintf("Duplicate field '%s'.", field.Name.Lexeme))
+		}
+		declared[field.Name.Lexeme] = true
+	}
+
+	for _, method := range methods {
+		if declared[method.Name.Lexeme] {
+			return r.newError(method.Name, fmt.Sprintf("'%s' is declared both as a field and as a method.", method.Name.Lexeme))
+		}
+	}
+
+	return nil
+}
+
+func (r *Resolver) resolveSetters(setters []parser.FunctionStmt, staticSetters []parser.FunctionStmt) (any, error) {
+	for _, setter := range slices.Concat(setters, staticSetters) {
+		if len(setter.Parameters) != 1 || setter.Parameters[0].Rest {
+			return nil, r.newError(setter.Name, fmt.Sprintf("Setter '%s' must have exactly one parameter.", setter.Name.Lexeme))
+		}
+	}
+
+	for _, setter := range setters {
+		if _, err := r.resolveFunctions(setter, functionTypeMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, setter := range staticSetters {
+		if _, err := r.resolveFunctions(setter, functionTypeStaticMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitFunctionStmt(stmt parser.FunctionStmt) (any, error) {
 	if err := r.declare(stmt.Name); err != nil {
 		return nil, err
@@ -472,7 +991,9 @@ func (r *Resolver) VisitIfStmt(stmt parser.IfStmt) (any, error) {
 }
 
 func (r *Resolver) VisitWhileStmt(stmt parser.WhileStmt) (any, error) {
-	r.beginLoop()
+	if err := r.beginLoop(stmt.Label); err != nil {
+		return nil, err
+	}
 	defer r.endLoop()
 
 	if _, err := r.resolveExpr(stmt.Condition); err != nil {
@@ -482,8 +1003,23 @@ func (r *Resolver) VisitWhileStmt(stmt parser.WhileStmt) (any, error) {
 	return r.resolveStmt(stmt.Body)
 }
 
+func (r *Resolver) VisitDoWhileStmt(stmt parser.DoWhileStmt) (any, error) {
+	if err := r.beginLoop(stmt.Label); err != nil {
+		return nil, err
+	}
+	defer r.endLoop()
+
+	if _, err := r.resolveStmt(stmt.Body); err != nil {
+		return nil, err
+	}
+
+	return r.resolveExpr(stmt.Condition)
+}
+
 func (r *Resolver) VisitForStmt(stmt parser.ForStmt) (any, error) {
-	r.beginLoop()
+	if err := r.beginLoop(stmt.Label); err != nil {
+		return nil, err
+	}
 	defer r.endLoop()
 
 	if stmt.Initializer != nil {
@@ -507,14 +1043,54 @@ func (r *Resolver) VisitForStmt(stmt parser.ForStmt) (any, error) {
 	return r.resolveStmt(stmt.Body)
 }
 
+func (r *Resolver) VisitSwitchStmt(stmt parser.SwitchStmt) (any, error) {
+	if _, err := r.resolveExpr(stmt.Subject); err != nil {
+		return nil, err
+	}
+
+	r.switchLevel += 1
+	defer func() {
+		r.switchLevel -= 1
+	}()
+
+	for _, switchCase := range stmt.Cases {
+		for _, value := range switchCase.Values {
+			if _, err := r.resolveExpr(value); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := r.resolveCaseBody(switchCase.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.resolveCaseBody(stmt.Default)
+}
+
+func (r *Resolver) resolveCaseBody(body []parser.Stmt) (any, error) {
+	r.beginScope()
+	defer r.endScope()
+
+	return r.resolveStmts(body)
+}
+
 func (r *Resolver) VisitBreakStmt(stmt parser.BreakStmt) (any, error) {
-	if !r.insideLoop() {
+	if stmt.Label.Lexeme != "" {
+		return r.resolveLabel(stmt.Label)
+	}
+
+	if !r.insideLoop() && r.switchLevel == 0 {
 		return nil, r.newError(stmt.Keyword, "Unexpected 'break' outside of loop.")
 	}
 	return nil, nil
 }
 
 func (r *Resolver) VisitContinueStmt(stmt parser.ContinueStmt) (any, error) {
+	if stmt.Label.Lexeme != "" {
+		return r.resolveLabel(stmt.Label)
+	}
+
 	if !r.insideLoop() {
 		return nil, r.newError(stmt.Keyword, "Unexpected 'continue' outside of loop.")
 	}
@@ -522,6 +1098,44 @@ func (r *Resolver) VisitContinueStmt(stmt parser.ContinueStmt) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitAssertStmt(stmt parser.AssertStmt) (any, error) {
+	if _, err := r.resolveExpr(stmt.Condition); err != nil {
+		return nil, err
+	}
+
+	if stmt.Message != nil {
+		return r.resolveExpr(stmt.Message)
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitDeferStmt(stmt parser.DeferStmt) (any, error) {
+	if r.currentFunction == functionTypeNone {
+		return nil, r.newError(stmt.Keyword, "Can't defer from top-level code.")
+	}
+
+	return r.resolveExpr(stmt.Expr)
+}
+
+func (r *Resolver) VisitUsingStmt(stmt parser.UsingStmt) (any, error) {
+	if _, err := r.resolveExpr(stmt.Initializer); err != nil {
+		return nil, err
+	}
+
+	r.beginScope()
+	defer r.endScope()
+
+	if err := r.declare(stmt.Name); err != nil {
+		return nil, err
+	}
+	r.define(stmt.Name)
+	// The resource is always read when it's closed, so it's never reported as unused.
+	(*r.peekScope())[stmt.Name.Lexeme].state = variableStateRead
+
+	return r.resolveStmt(stmt.Body)
+}
+
 func (r *Resolver) VisitReturnStmt(stmt parser.ReturnStmt) (any, error) {
 	if r.currentFunction == functionTypeNone {
 		return nil, r.newError(stmt.Keyword, "Can't return from top-level code.")
@@ -2,12 +2,14 @@ package scanner
 
 import (
 	"strconv"
+	"strings"
 )
 
 var Keywords = map[string]TokenType{
 	"and":      AND,
 	"class":    CLASS,
 	"trait":    TRAIT,
+	"enum":     ENUM,
 	"else":     ELSE,
 	"false":    FALSE,
 	"fun":      FUN,
@@ -17,13 +19,22 @@ var Keywords = map[string]TokenType{
 	"or":       OR,
 	"print":    PRINT,
 	"return":   RETURN,
+	"defer":    DEFER,
+	"using":    USING,
+	"assert":   ASSERT,
 	"super":    SUPER,
 	"this":     THIS,
 	"true":     TRUE,
 	"var":      VAR,
+	"const":    CONST,
 	"while":    WHILE,
+	"do":       DO,
+	"switch":   SWITCH,
 	"break":    BREAK,
 	"continue": CONTINUE,
+	"match":    MATCH,
+	"case":     CASE,
+	"is":       IS,
 }
 
 type Scanner struct {
@@ -129,7 +140,7 @@ func (s *Scanner) string() error {
 	return nil
 }
 
-func (s *Scanner) number() {
+func (s *Scanner) number() error {
 	for s.isDigit(s.peek()) && !s.isAtEnd() {
 		s.advance()
 	}
@@ -139,8 +150,24 @@ func (s *Scanner) number() {
 			s.advance()
 		}
 	}
-	num, _ := strconv.ParseFloat(s.source[s.start:s.current], 64)
+	digits := s.source[s.start:s.current]
+
+	if suffix := s.peek(); (suffix == 'n' || suffix == 'd') && !s.isAlpha(s.peekNext()) && !s.isDigit(s.peekNext()) {
+		s.advance()
+		if suffix == 'n' {
+			if strings.Contains(digits, ".") {
+				return &Error{Line: s.line, Message: "Big integer literals can't have a fractional part."}
+			}
+			s.addToken(NUMBER, BigIntLiteral(digits))
+		} else {
+			s.addToken(NUMBER, DecimalLiteral(digits))
+		}
+		return nil
+	}
+
+	num, _ := strconv.ParseFloat(digits, 64)
 	s.addToken(NUMBER, num)
+	return nil
 }
 
 func (s *Scanner) identifier() {
@@ -156,9 +183,26 @@ func (s *Scanner) identifier() {
 	s.addToken(tokenType, nil)
 }
 
+// privateIdentifier scans a private member name like "#secret". The '#' is kept in the lexeme, so
+// private names never clash with public ones.
+func (s *Scanner) privateIdentifier() error {
+	if s.isAtEnd() || !s.isAlpha(s.peek()) {
+		return &Error{Line: s.line, Message: "Expected a member name after '#'."}
+	}
+
+	for !s.isAtEnd() && (s.isAlpha(s.peek()) || s.isDigit(s.peek())) {
+		s.advance()
+	}
+
+	s.addToken(PRIVATE_IDENTIFIER, nil)
+
+	return nil
+}
+
 func (s *Scanner) Run() ([]Token, error) {
 	var err error
 	for !s.isAtEnd() {
+		var charErr error
 		char := s.advance()
 
 		switch char {
@@ -184,7 +228,20 @@ func (s *Scanner) Run() ([]Token, error) {
 			s.addToken(COMMA, nil)
 			break
 		case '.':
-			s.addToken(DOT, nil)
+			if s.match('.') {
+				s.advance()
+				if s.match('.') {
+					s.advance()
+					s.addToken(ELLIPSIS, nil)
+				} else if s.match('<') {
+					s.advance()
+					s.addToken(DOT_DOT_LESS, nil)
+				} else {
+					s.addToken(DOT_DOT, nil)
+				}
+			} else {
+				s.addToken(DOT, nil)
+			}
 			break
 		case '-':
 			s.addToken(MINUS, nil)
@@ -199,7 +256,15 @@ func (s *Scanner) Run() ([]Token, error) {
 			s.addToken(STAR, nil)
 			break
 		case '?':
-			s.addToken(QUESTION, nil)
+			if s.match('?') {
+				s.advance()
+				s.addToken(QUESTION_QUESTION, nil)
+			} else if s.match('.') {
+				s.advance()
+				s.addToken(QUESTION_DOT, nil)
+			} else {
+				s.addToken(QUESTION, nil)
+			}
 			break
 		case ':':
 			s.addToken(COLON, nil)
@@ -213,7 +278,7 @@ func (s *Scanner) Run() ([]Token, error) {
 					s.advance()
 				}
 			} else if s.match('*') {
-				err = s.blockComment()
+				charErr = s.blockComment()
 			} else {
 				s.addToken(SLASH, nil)
 			}
@@ -230,6 +295,9 @@ func (s *Scanner) Run() ([]Token, error) {
 			if s.match('=') {
 				s.addToken(EQUAL_EQUAL, nil)
 				s.advance()
+			} else if s.match('>') {
+				s.advance()
+				s.addToken(ARROW, nil)
 			} else {
 				s.addToken(EQUAL, nil)
 			}
@@ -250,9 +318,18 @@ func (s *Scanner) Run() ([]Token, error) {
 			} else {
 				s.addToken(LESS, nil)
 			}
+		case '|':
+			if s.match('>') {
+				s.advance()
+				s.addToken(PIPE_GREATER, nil)
+			} else {
+				charErr = &Error{Line: s.line, Message: "Unexpected character."}
+			}
 		case '"':
-			err = s.string()
+			charErr = s.string()
 			break
+		case '#':
+			charErr = s.privateIdentifier()
 		case ' ':
 		case '\r':
 		case '\t':
@@ -262,13 +339,17 @@ func (s *Scanner) Run() ([]Token, error) {
 			break
 		default:
 			if s.isDigit(char) {
-				s.number()
+				charErr = s.number()
 			} else if s.isAlpha(char) {
 				s.identifier()
 			} else {
-				err = &Error{Line: s.line, Message: "Unexpected character."}
+				charErr = &Error{Line: s.line, Message: "Unexpected character."}
 			}
 		}
+		// Scanning goes on after an error, but the first one is the one reported.
+		if err == nil {
+			err = charErr
+		}
 		s.start = s.current
 	}
 	s.addToken(EOF, nil)
@@ -28,18 +28,29 @@ const (
 	LESS          TokenType = "LESS"
 	LESS_EQUAL    TokenType = "LESS_EQUAL"
 	QUESTION      TokenType = "QUESTION"
-	COLON         TokenType = "COLON"
+	// QUESTION_QUESTION and QUESTION_DOT are "??" and "?.". An optional index "?[" is a QUESTION
+	// followed by a LEFT_BRACKET, as only the parser can tell it from a ternary.
+	QUESTION_QUESTION TokenType = "QUESTION_QUESTION"
+	QUESTION_DOT      TokenType = "QUESTION_DOT"
+	PIPE_GREATER      TokenType = "PIPE_GREATER"
+	COLON             TokenType = "COLON"
+	ARROW             TokenType = "ARROW"
+	DOT_DOT           TokenType = "DOT_DOT"
+	DOT_DOT_LESS      TokenType = "DOT_DOT_LESS"
+	ELLIPSIS          TokenType = "ELLIPSIS"
 	// Literals.
 
-	IDENTIFIER TokenType = "IDENTIFIER"
-	STRING     TokenType = "STRING"
-	NUMBER     TokenType = "NUMBER"
+	IDENTIFIER         TokenType = "IDENTIFIER"
+	PRIVATE_IDENTIFIER TokenType = "PRIVATE_IDENTIFIER"
+	STRING             TokenType = "STRING"
+	NUMBER             TokenType = "NUMBER"
 	// Keywords.
 
 	AND       TokenType = "AND"
 	CLASS     TokenType = "CLASS"
 	TRAIT     TokenType = "TRAIT"
 	USE_TRAIT TokenType = "USE_TRAIT"
+	ENUM      TokenType = "ENUM"
 	ELSE      TokenType = "ELSE"
 	FALSE     TokenType = "FALSE"
 	FUN       TokenType = "FUN"
@@ -53,9 +64,18 @@ const (
 	THIS      TokenType = "THIS"
 	TRUE      TokenType = "TRUE"
 	VAR       TokenType = "VAR"
+	CONST     TokenType = "CONST"
 	WHILE     TokenType = "WHILE"
+	DO        TokenType = "DO"
+	SWITCH    TokenType = "SWITCH"
 	BREAK     TokenType = "BREAK"
 	CONTINUE  TokenType = "CONTINUE"
+	DEFER     TokenType = "DEFER"
+	USING     TokenType = "USING"
+	ASSERT    TokenType = "ASSERT"
+	MATCH     TokenType = "MATCH"
+	CASE      TokenType = "CASE"
+	IS        TokenType = "IS"
 
 	EOF TokenType = "EOF"
 )
@@ -66,3 +86,9 @@ type Token struct {
 	Literal any
 	Line    int32
 }
+
+// BigIntLiteral holds the digits of an integer literal suffixed with 'n' (e.g. 10n).
+type BigIntLiteral string
+
+// DecimalLiteral holds the digits of a number literal suffixed with 'd' (e.g. 0.10d).
+type DecimalLiteral string
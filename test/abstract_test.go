@@ -0,0 +1,73 @@
+package test
+
+import "testing"
+
+func TestAbstractClasses(t *testing.T) {
+	program := `
+abstract class Shape {
+	init(name) {
+		this.name = name;
+	}
+
+	abstract area();
+
+	describe() {
+		return this.name + " with area " + str(this.area());
+	}
+}
+
+abstract class Polygon < Shape {
+	abstract sides();
+}
+
+trait Squared {
+	area() {
+		return this.side * this.side;
+	}
+}
+
+class Square < Polygon <> Squared {
+	init(side) {
+		super.init("square");
+		this.side = side;
+	}
+
+	sides() {
+		return 4;
+	}
+}
+
+class Circle < Shape {
+	init(radius) {
+		super.init("circle");
+		this.radius = radius;
+	}
+
+	area() {
+		return 3 * this.radius * this.radius;
+	}
+}
+
+var abstract = "still a name";
+print Square(2).describe();
+print Square(2).sides();
+print Circle(1).describe();
+print abstract;
+`
+	assertPrograms(t, []testCase{
+		{program, "square with area 4\n4\ncircle with area 3\nstill a name\n"},
+	})
+}
+
+func TestAbstractClassErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"abstract class A {}\nA();", "[line 2] Can't instantiate abstract class 'A'.\n"},
+		{"abstract class A { abstract f(); }\nclass B < A {}", "[line 2] Class 'B' must implement abstract method 'f' of class 'A'.\n"},
+		{"abstract class A { abstract f(); }\nabstract class B < A {}\nclass C < B {}", "[line 3] Class 'C' must implement abstract method 'f' of class 'A'.\n"},
+		{"class A { abstract f(); }", "[line 1] Error at 'f': Only abstract classes can declare abstract methods.\n"},
+		{"trait A { abstract f(); }", "[line 1] Error at 'f': Only abstract classes can declare abstract methods.\n"},
+		{"abstract class A { class abstract f(); }", "[line 1] Error at 'abstract': Static methods can't be abstract.\n"},
+		{"abstract class A { abstract f() {} }", "[line 1] Error at '{': Expected ';' after an abstract method.\n"},
+		{"abstract class A { abstract f(); f() {} }", "[line 1] 'f' is declared both as an abstract method and as a method.\n"},
+	})
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"glox/cmd"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssertStatements(t *testing.T) {
+	assertPrograms(t, []testCase{
+		{"assert true;\nassert 1 + 1 == 2, \"math\";\nprint \"ok\";", "ok\n"},
+		{"var calls = 0;\nfun message() {\n\tcalls = calls + 1;\n\treturn \"\";\n}\nassert true, message();\nprint calls;", "0\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{"assert false;", "[line 1] Assertion failed.\n"},
+		{"assert 1 > 2, \"1 should be greater than \" + str(2);", "[line 1] Assertion failed: 1 should be greater than 2\n"},
+		{"assert nil, [1, 2];", "[line 1] Assertion failed: [1, 2]\n"},
+		{"assert true, ;", "[line 1] Error at ';': Expected an expression.\n"},
+		{"assert true", "[line 1] Error at the end: Expected ';' after assertion.\n"},
+	})
+}
+
+func TestTestRunner(t *testing.T) {
+	dir := t.TempDir()
+	source := `
+var setups = 0;
+setups = setups + 1;
+
+fun testPasses() {
+	assert setups == 1, "top-level code should run once per test";
+	setups = setups + 1;
+}
+
+fun testAlsoPasses() {
+	assert setups == 1;
+}
+
+fun testFails() {
+	assert false, "broken";
+}
+
+fun helper() {
+	assert false;
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "example_test.glox"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.glox"), []byte("assert false;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, exitCode := runTests(dir)
+	if exitCode != 1 {
+		t.Fatalf("Expected exit code 1, got %d.", exitCode)
+	}
+	for _, expected := range []string{"--- PASS: testPasses", "--- PASS: testAlsoPasses", "--- FAIL: testFails", "example_test.glox: [line 15] Assertion failed: broken\n", "FAIL: 2 passed, 1 failed in "} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected the output to contain %q, got %q.", expected, output)
+		}
+	}
+	if strings.Contains(output, "helper") {
+		t.Fatalf("Expected only test functions to run, got %q.", output)
+	}
+
+	arguments := `
+fun testArgs(a, b) {
+	assert a == nil;
+}
+
+fun testDefaults(a = 1, ...rest) {
+	assert a == 1 and len(rest) == 0;
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "arguments_test.glox"), []byte(arguments), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, exitCode = runTests(filepath.Join(dir, "arguments_test.glox"))
+	if exitCode != 1 {
+		t.Fatalf("Expected exit code 1, got %d.", exitCode)
+	}
+	for _, expected := range []string{"--- FAIL: testArgs", "arguments_test.glox: [line 2] Test functions can't have required parameters.\n", "--- PASS: testDefaults"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected the output to contain %q, got %q.", expected, output)
+		}
+	}
+
+	output, exitCode = runTests("-run", "Passes$", dir)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d.", exitCode)
+	}
+	if !strings.Contains(output, "PASS: 2 passed in ") || strings.Contains(output, "testFails") {
+		t.Fatalf("Expected only the filtered tests to run, got %q.", output)
+	}
+}
+
+func runTests(args ...string) (string, int) {
+	var exitCode int
+	output := captureOutput(func() {
+		exitCode = cmd.RunTests(args)
+	})
+
+	return output, exitCode
+}
@@ -3,11 +3,13 @@ package test
 import (
 	"bytes"
 	"fmt"
+	"glox/checker"
 	"glox/interpreter"
 	"glox/parser"
 	"glox/resolver"
 	"glox/scanner"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -66,6 +68,92 @@ func testFailingPrograms(t *testing.T, testCases []testCase) {
 	}
 }
 
+// assertFailingOutput checks what a failing program printed before its error, followed by the error.
+func assertFailingOutput(t *testing.T, testCases []testCase) {
+	for idx, tt := range testCases {
+		result, err := interpret(tt.source)
+
+		if err == nil {
+			t.Fatalf("Error at the test case №%d. Error did not occur.", idx+1)
+		}
+
+		if result+err.Error() != tt.expected {
+			newError(t, idx, tt.expected, result+err.Error())
+		}
+	}
+}
+
+func assertWarnings(t *testing.T, testCases []testCase) {
+	for idx, tt := range testCases {
+		result, err := resolveWarnings(tt.source)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result != tt.expected {
+			newError(t, idx, tt.expected, result)
+		}
+	}
+}
+
+func resolveWarnings(source string) (string, error) {
+	tokens, err := scanner.New(source).Run()
+	if err != nil {
+		return "", err
+	}
+
+	statements, errs := parser.New(tokens).Parse()
+	if len(errs) != 0 {
+		return "", errs[0]
+	}
+
+	_resolver := resolver.New(interpreter.New())
+	if _, err := _resolver.Resolve(statements); err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	for _, warning := range _resolver.Warnings() {
+		result.WriteString(warning.Error())
+	}
+
+	return result.String(), nil
+}
+
+func assertTypeErrors(t *testing.T, testCases []testCase) {
+	for idx, tt := range testCases {
+		result, err := typeCheck(tt.source)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result != tt.expected {
+			newError(t, idx, tt.expected, result)
+		}
+	}
+}
+
+func typeCheck(source string) (string, error) {
+	tokens, err := scanner.New(source).Run()
+	if err != nil {
+		return "", err
+	}
+
+	statements, errs := parser.New(tokens).Parse()
+	if len(errs) != 0 {
+		return "", errs[0]
+	}
+
+	var result strings.Builder
+	for _, err := range checker.New().Check(statements) {
+		result.WriteString(err.Error())
+	}
+
+	return result.String(), nil
+}
+
 func interpret(source string) (string, error) {
 	_runner := func(source string, _interpreter *interpreter.Interpreter) error {
 		_scanner := scanner.New(source)
@@ -96,7 +184,9 @@ func interpret(source string) (string, error) {
 	os.Stdout = w
 
 	if err := _runner(source, interpreter.New()); err != nil {
-		return "", err
+		os.Stdout = originalStdout
+		_ = w.Close()
+		return readOutput(r), err
 	}
 
 	err := w.Close()
@@ -104,18 +194,30 @@ func interpret(source string) (string, error) {
 		panic(err)
 	}
 
-	var buf bytes.Buffer
-	if _, err := buf.ReadFrom(r); err != nil {
-		panic(err)
-	}
+	os.Stdout = originalStdout
+
+	return readOutput(r), nil
+}
+
+// captureOutput returns what run prints.
+func captureOutput(run func()) string {
+	originalStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	run()
 
 	os.Stdout = originalStdout
+	_ = w.Close()
 
-	result := buf.String()
+	return readOutput(r)
+}
 
-	if len(result) > 0 {
-		return result, nil
+func readOutput(r *os.File) string {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		panic(err)
 	}
 
-	return "", nil
+	return buf.String()
 }
@@ -0,0 +1,57 @@
+package test
+
+import "testing"
+
+func TestBigIntegers(t *testing.T) {
+	assertExpressions(t, []testCase{
+		{"9007199254740993n + 1n", "9007199254740994"},
+		{"123456789012345678901234567890n * 10n", "1234567890123456789012345678900"},
+		{"7n / 2n", "3"},
+		{"-7n % 2n", "-1"},
+		{"-5n", "-5"},
+		{"2n + 3", "5"},
+		{"2n == 2", "true"},
+		{"10n > 9n", "true"},
+		{"bigint(\"18446744073709551616\") - 1n", "18446744073709551615"},
+		{"bigint(42)", "42"},
+	})
+}
+
+func TestDecimals(t *testing.T) {
+	assertExpressions(t, []testCase{
+		{"0.1d + 0.2d", "0.3"},
+		{"0.1d + 0.2d == 0.3d", "true"},
+		{"1.10d + 2.20d", "3.30"},
+		{"19.99d * 3", "59.97"},
+		{"10.00d / 4", "2.50"},
+		{"1d / 3", "0.3333333333333333"},
+		{"10.5d % 3", "1.5"},
+		{"2n + 0.5", "2.5"},
+		{"decimal(\"-0.05\")", "-0.05"},
+		{"decimal(1.25) < 1.3d", "true"},
+		{"round(2.345d, 2)", "2.34"},
+		{"[1.50d, 2n, 3]", "[1.50, 2, 3]"},
+	})
+
+	program := `
+decimalContext(2, "half_up");
+print 2d / 3;
+print round(2.345d, 2);
+
+decimalContext(4, "floor");
+print -1d / 3;
+`
+	assertPrograms(t, []testCase{
+		{program, "0.67\n2.35\n-0.3334\n"},
+	})
+}
+
+func TestFailingBigNumbers(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print 1n / 0n;", "[line 1] Division by zero is prohibited.\n"},
+		{"print 1.5d / 0;", "[line 1] Division by zero is prohibited.\n"},
+		{"print bigint(1.5);", "[line 1] Argument to 'bigint' should be an integer or an integer string.\n"},
+		{"print 1n + \"a\";", "[line 1] Both operands should be numbers or strings.\n"},
+		{"decimalContext(2, \"sideways\");", "[line 1] Second argument to 'decimalContext' should be a rounding mode: up, down, ceiling, floor, half_up, half_down or half_even.\n"},
+	})
+}
@@ -0,0 +1,103 @@
+package test
+
+import "testing"
+
+func TestTypeAnnotations(t *testing.T) {
+	program := `
+fun area(width: Number, height: Number): Number {
+	return width * height;
+}
+
+var label: String = "area";
+var lazy: Number?;
+var scale = fun(value: Number, factor): Number { return value * factor; };
+
+class Box {
+	init(side: Number) {
+		this.side = side;
+	}
+
+	volume: Number {
+		return this.side * this.side * this.side;
+	}
+}
+
+print label + " " + str(area(2, 3));
+print scale(2, 4);
+print Box(3).volume;
+print lazy;
+`
+	assertPrograms(t, []testCase{
+		{program, "area 6\n8\n27\nnil\n"},
+	})
+}
+
+func TestTypeChecker(t *testing.T) {
+	program1 := `
+fun f(a: Number, b: String): Bool {
+	return a > 1;
+}
+
+var count: Number = "one";
+var total = 1 + "two";
+f(1);
+f("1", "2");
+var flag: Bool = f(2, "x");
+var n: Number = f(2, "x");
+`
+
+	program2 := `
+class Point {
+	init(x: Number, y: Number) {
+		this.x = x;
+		this.y = y;
+	}
+
+	length: Number {
+		return this.x + this.y;
+	}
+
+	scale(factor: Number): Point {
+		return Point(this.x * factor, this.y * factor);
+	}
+}
+
+var p = Point(1, 2);
+print p.length + p.x;
+print p.scale(2).z;
+p.scale(2, 3);
+Point(1);
+print -p;
+`
+
+	program3 := `
+fun greet(name: String?): String {
+	if (name == nil) return;
+	return "Hi " + name;
+}
+
+var untyped = 1;
+untyped = "now a string";
+print untyped + "!";
+
+var items: Array = [1, 2];
+print items[0] + 1;
+print "text"[0];
+var u: Unknown = 1;
+`
+
+	assertTypeErrors(t, []testCase{
+		{program1, "[line 6] Type error: Can't assign String to variable 'count' of type Number.\n" +
+			"[line 7] Type error: Both operands should be numbers or strings, got Number and String.\n" +
+			"[line 8] Type error: Expected 2 arguments, but got 1.\n" +
+			"[line 9] Type error: Argument 1 of 'f' expects Number, got String.\n" +
+			"[line 11] Type error: Can't assign Bool to variable 'n' of type Number.\n"},
+		{program2, "[line 19] Type error: Undefined property 'z' on Point.\n" +
+			"[line 20] Type error: Expected 1 arguments, but got 2.\n" +
+			"[line 21] Type error: Expected 2 arguments, but got 1.\n" +
+			"[line 22] Type error: Operand must be a number, got Point.\n"},
+		{program3, "[line 3] Type error: Can't return Nil from 'greet' declared to return String.\n" +
+			"[line 13] Type error: Only arrays can be indexed, got String.\n" +
+			"[line 14] Type error: Unknown type 'Unknown'.\n"},
+	})
+}
@@ -0,0 +1,69 @@
+package test
+
+import "testing"
+
+func TestConstBindings(t *testing.T) {
+	program := `
+const limit = 3;
+const greeting: String = "hi";
+
+fun shout() {
+	const suffix = "!";
+	return greeting + suffix;
+}
+
+{
+	const limit = 10;
+	print limit;
+}
+
+var total = limit + 1;
+total = total + 1;
+print total;
+print shout();
+`
+	assertPrograms(t, []testCase{
+		{program, "10\n5\nhi!\n"},
+	})
+}
+
+func TestFrozenObjects(t *testing.T) {
+	program := `
+class Config {
+	init(name) {
+		this.name = name;
+	}
+}
+
+var config = freeze(Config("prod"));
+var ports = freeze([80, 443]);
+var copy = append(ports, 8080);
+copy[0] = 8000;
+print config.name;
+print ports;
+print copy;
+`
+	assertPrograms(t, []testCase{
+		{program, "prod\n[80, 443]\n[8000, 443, 8080]\n"},
+	})
+}
+
+func TestConstErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"const x = 1;\nx = 2;", "[line 2] Can't reassign constant 'x'.\n"},
+		{"const x = 1;\nfun f() { x = 2; }\nf();", "[line 2] Can't reassign constant 'x'.\n"},
+		{"{\n\tconst x = 1;\n\tx = 2;\n}", "[line 3] Can't reassign constant 'x'.\n"},
+		{"{\n\tconst x = 1;\n\tvar y;\n\t[x, y] = [2, 3];\n}", "[line 4] Can't reassign constant 'x'.\n"},
+		{"const x = 1;\nvar x = 3;\nx = 4;", "[line 2] Can't redeclare constant 'x'.\n"},
+		{"const x = 1;\nconst x = 2;", "[line 2] Can't redeclare constant 'x'.\n"},
+		{"const f = 1;\nfun f() {}", "[line 2] Can't redeclare constant 'f'.\n"},
+		{"const x = 1;\nvar [x] = [2];", "[line 2] Can't redeclare constant 'x'.\n"},
+		{"{\n\tconst x = 1;\n\tvar x = 3;\n}", "[line 3] Can't redeclare constant 'x'.\n"},
+		{"const x;", "[line 1] Error at ';': Expected '=' after a constant name.\n"},
+		{"class A {}\nvar a = freeze(A());\na.x = 1;", "[line 3] Can't modify a frozen instance.\n"},
+		{"class A {\n\tinit() { this.#x = 1; }\n\tbump() { this.#x = 2; }\n}\nfreeze(A()).bump();", "[line 3] Can't modify a frozen instance.\n"},
+		{"var a = freeze([1, 2]);\na[0] = 3;", "[line 2] Can't modify a frozen array.\n"},
+		{"var a = freeze([1, 2]);\n[a[0]] = [3];", "[line 2] Can't modify a frozen array.\n"},
+		{"freeze(1);", "[line 1] Argument to 'freeze' should be an instance or an array.\n"},
+	})
+}
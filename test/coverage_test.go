@@ -0,0 +1,135 @@
+package test
+
+import (
+	"bytes"
+	"glox/coverage"
+	"glox/interpreter"
+	"glox/parser"
+	"glox/resolver"
+	"glox/scanner"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	source := `fun sign(n) {
+	if (n < 0) {
+		return -1;
+	}
+	return n > 0 ? 1 : 0;
+}
+
+class Counter {
+	increment() {
+		return nil ?? 1;
+	}
+}
+
+sign(1);
+sign(2);
+var fallback = false or true;`
+
+	profile := coverProgram(t, source)
+
+	var lcov bytes.Buffer
+	if err := coverage.WriteLCOV(&lcov, []*coverage.Profile{profile}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `TN:
+SF:sign.glox
+BRDA:2,0,0,0
+BRDA:2,0,1,2
+BRDA:5,0,0,2
+BRDA:5,0,1,0
+BRDA:10,0,0,-
+BRDA:10,0,1,-
+BRDA:16,0,0,0
+BRDA:16,0,1,1
+BRF:8
+BRH:3
+DA:1,1
+DA:2,2
+DA:3,0
+DA:5,2
+DA:8,1
+DA:10,0
+DA:14,1
+DA:15,1
+DA:16,1
+LF:9
+LH:7
+end_of_record
+`
+	if lcov.String() != expected {
+		t.Fatalf("Expected LCOV:\n%s\nGot:\n%s", expected, lcov.String())
+	}
+
+	var summary bytes.Buffer
+	if err := coverage.WriteSummary(&summary, []*coverage.Profile{profile}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(summary.String(), "sign.glox  7/9  77.8%  3/8  37.5%") {
+		t.Fatalf("Unexpected summary:\n%s", summary.String())
+	}
+
+	var html bytes.Buffer
+	if err := coverage.WriteHTML(&html, []*coverage.Profile{profile}); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`<tr class="uncovered"><td class="number">3</td><td class="count">0</td><td class="code">		return -1;</td></tr>`, `<tr class="partial" title="Branches taken (first/second arm): ternary: 2/0">`} {
+		if !strings.Contains(html.String(), expected) {
+			t.Fatalf("Expected the HTML report to contain %q, got:\n%s", expected, html.String())
+		}
+	}
+}
+
+func TestTestRunnerCoverage(t *testing.T) {
+	dir := t.TempDir()
+	source := "fun testTruth() {\n\tassert true or false;\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "truth_test.glox"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lcovPath, htmlPath := filepath.Join(dir, "lcov.info"), filepath.Join(dir, "coverage.html")
+	output, exitCode := runTests("-coverprofile", lcovPath, "-coverhtml", htmlPath, dir)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d with output %q.", exitCode, output)
+	}
+	if !strings.Contains(output, "truth_test.glox  2/2 100.0%  1/2  50.0%") {
+		t.Fatalf("Expected a coverage summary, got %q.", output)
+	}
+
+	for _, path := range []string{lcovPath, htmlPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected a coverage report at %s: %s", path, err)
+		}
+	}
+}
+
+func coverProgram(t *testing.T, source string) *coverage.Profile {
+	tokens, err := scanner.New(source).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements, errs := parser.New(tokens).Parse()
+	if len(errs) != 0 {
+		t.Fatal(errs[0])
+	}
+
+	profile := coverage.New("sign.glox", source, statements)
+
+	_interpreter := interpreter.New()
+	_interpreter.SetTracer(profile)
+	if _, err := resolver.New(_interpreter).Resolve(statements); err != nil {
+		t.Fatal(err)
+	}
+	if err := _interpreter.Interpret(statements); err != nil {
+		t.Fatal(err)
+	}
+
+	return profile
+}
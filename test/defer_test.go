@@ -0,0 +1,128 @@
+package test
+
+import "testing"
+
+func TestDeferStatements(t *testing.T) {
+	program := `
+fun log(message) {
+	print message;
+}
+
+fun work() {
+	defer log("first deferred");
+	defer log("second deferred");
+	print "working";
+	return "result";
+}
+print work();
+
+fun count() {
+	var n = 1;
+	defer log(n);
+	n = 2;
+}
+count();
+
+fun loop() {
+	for (var i = 0; i < 3; i = i + 1) {
+		if (i == 1) {
+			defer log("from the loop");
+		}
+	}
+	print "loop finished";
+}
+loop();
+`
+	failing := `
+fun log(message) {
+	print message;
+}
+
+fun fail() {
+	defer log("cleaned up");
+	print nil + 1;
+}
+fail();
+`
+	replaced := `
+fun fail() {
+	defer nil + 1;
+	return 1;
+}
+fail();
+`
+
+	assertPrograms(t, []testCase{
+		{program, "working\nsecond deferred\nfirst deferred\nresult\n2\nloop finished\nfrom the loop\n"},
+	})
+
+	assertFailingOutput(t, []testCase{
+		{failing, "cleaned up\n[line 8] Both operands should be numbers or strings.\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{replaced, "[line 3] Both operands should be numbers or strings.\n"},
+		{"defer clock();", "[line 1] Can't defer from top-level code.\n"},
+		{"fun f() { defer print 1; }", "[line 1] Error at 'print': Expected an expression.\n"},
+		{"fun f() { defer f() }", "[line 1] Error at '}': Expected ';' after deferred expression.\n"},
+	})
+}
+
+func TestUsingStatements(t *testing.T) {
+	program := `
+class Resource {
+	init(name) {
+		this.name = name;
+	}
+
+	close() {
+		print "closing " + this.name;
+	}
+}
+
+using (var r = Resource("a")) {
+	print "using " + r.name;
+}
+
+fun read() {
+	using (var r = Resource("b")) {
+		return r.name;
+	}
+}
+print read();
+
+while (true) {
+	using (var r = Resource("c")) break;
+}
+
+using (var r = nil) {
+	print r;
+}
+`
+
+	assertPrograms(t, []testCase{
+		{program, "using a\nclosing a\nclosing b\nb\nclosing c\nnil\n"},
+	})
+
+	failing := `
+class Resource {
+	close() {
+		print "closed";
+	}
+}
+
+using (var r = Resource()) {
+	r.missing();
+}
+`
+
+	assertFailingOutput(t, []testCase{
+		{failing, "closed\n[line 9] Undefined property 'missing'.\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{"using (var r = 1) { }", "[line 1] Resources of 'using' should have a close() method without parameters.\n"},
+		{"class A {}\nusing (var r = A()) { }", "[line 2] Resources of 'using' should have a close() method without parameters.\n"},
+		{"using (r = 1) { }", "[line 1] Error at 'r': Expected 'var' before resource name.\n"},
+	})
+}
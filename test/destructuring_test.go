@@ -0,0 +1,132 @@
+package test
+
+import "testing"
+
+func TestDestructuringDeclarations(t *testing.T) {
+	program1 := `
+var [a, b, c] = [1, 2, 3];
+print a + b + c;
+
+var [first, ...rest] = [1, 2, 3, 4];
+print first;
+print rest;
+
+var [_, [x, y]] = ["skipped", [5, 6]];
+print x * y;
+
+{
+	var [local, other] = ["local", "other"];
+	print local + " " + other;
+}
+`
+
+	program2 := `
+class Point {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+
+	length {
+		return this.x + this.y;
+	}
+}
+
+var {x, y} = Point(3, 4);
+print x;
+print y;
+
+var {x: px, length} = Point(10, 20);
+print px;
+print length;
+
+var {x: [one, two]} = Point([1, 2], nil);
+print one + two;
+`
+	assertPrograms(t, []testCase{
+		{program1, "6\n1\n[2, 3, 4]\n30\nlocal other\n"},
+		{program2, "3\n4\n10\n30\n3\n"},
+	})
+}
+
+func TestMultipleAssignment(t *testing.T) {
+	program1 := `
+var a = 1;
+var b = 2;
+[a, b] = [b, a];
+print a;
+print b;
+
+var head;
+var tail;
+[head, ...tail] = [1, 2, 3];
+print head;
+print tail;
+
+fun f() {
+	var x = "x";
+	var y = "y";
+	[x, [y]] = ["new x", ["new y"]];
+	return x + ", " + y;
+}
+print f();
+`
+
+	program2 := `
+class Box {}
+var box = Box();
+var values = [0, 0];
+[box.value, values[1]] = ["boxed", "indexed"];
+print box.value;
+print values;
+print [box.first, box.second] = [1, 2];
+`
+	assertPrograms(t, []testCase{
+		{program1, "2\n1\n1\n[2, 3]\nnew x, new y\n"},
+		{program2, "boxed\n[0, indexed]\n[1, 2]\n"},
+	})
+}
+
+func TestDestructuringParametersAndSpread(t *testing.T) {
+	program := `
+fun add([a, b]) {
+	return a + b;
+}
+print add([1, 2]);
+
+class Point {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+}
+
+var norm = fun ({x, y}, scale) {
+	return (x + y) * scale;
+};
+print norm(Point(1, 2), 10);
+
+var middle = [2, 3];
+print [1, ...middle, 4, ...[]];
+`
+	assertPrograms(t, []testCase{
+		{program, "3\n30\n[1, 2, 3, 4]\n"},
+	})
+}
+
+func TestDestructuringErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"var [a, b] = [1];", "[line 1] Expected 2 elements to destructure, but got 1.\n"},
+		{"var [a, b, ...c] = [1];", "[line 1] Expected at least 2 elements to destructure, but got 1.\n"},
+		{"var [a] = 1;", "[line 1] Only arrays can be destructured with '[]', got '1'.\n"},
+		{"var {a} = [1];", "[line 1] Only instances can be destructured with '{}', got '[1]'.\n"},
+		{"class A {}\nvar {missing} = A();", "[line 2] Undefined property 'missing'.\n"},
+		{"var a; var b;\n[a, b] = [1, 2, 3];", "[line 2] Expected 2 elements to destructure, but got 3.\n"},
+		{"print [1, ...2];", "[line 1] Only arrays can be spread.\n"},
+		{"var a;\n[a, 1] = [1, 2];", "[line 2] Error at '=': Invalid assignment target.\n"},
+	})
+
+	assertWarnings(t, []testCase{
+		{"{ var [a, b] = [1, 2]; print a; }", "[line 1] Warning: Unused variable 'b'.\n"},
+	})
+}
@@ -0,0 +1,127 @@
+package test
+
+import "testing"
+
+func TestEnums(t *testing.T) {
+	program := `
+enum Color { Red, Green, Blue }
+
+print Color.Red;
+print Color.Red == Color.Red;
+print Color.Red == Color.Green;
+print Color.values;
+print Color.Blue.name + " " + str(Color.Blue.ordinal);
+print type(Color.Red);
+print type(Color);
+print Color.Green is Color;
+
+var total = 0;
+var colors = Color.values;
+for (var i = 0; i < len(colors); i = i + 1) {
+	total = total + colors[i].ordinal;
+}
+print total;
+`
+	assertPrograms(t, []testCase{
+		{program, "Color.Red\ntrue\nfalse\n[Color.Red, Color.Green, Color.Blue]\nBlue 2\nColor\nenum\ntrue\n3\n"},
+	})
+}
+
+func TestEnumIteration(t *testing.T) {
+	program := `
+enum Color { Red, Green, Blue }
+enum Result { Ok(value), Err(message) }
+
+print [c for c in Color];
+print [c.name for c in Color if c.ordinal > 0];
+print [...Color, Color.Red];
+var [first, ...rest] = Color;
+print first;
+print rest;
+print append(Color, nil);
+print [r for r in Result];
+`
+	assertPrograms(t, []testCase{
+		{program, "[Color.Red, Color.Green, Color.Blue]\n[Green, Blue]\n[Color.Red, Color.Green, Color.Blue, Color.Red]\n" +
+			"Color.Red\n[Color.Green, Color.Blue]\n[Color.Red, Color.Green, Color.Blue, nil]\n[<variant Result.Ok>, <variant Result.Err>]\n"},
+	})
+}
+
+func TestEnumAssociatedValues(t *testing.T) {
+	program := `
+enum Result {
+	Ok(value), Err(message = "failed");
+
+	isOk {
+		return this is Result.Ok;
+	}
+
+	unwrap(fallback) {
+		return match (this) {
+			case Result.Ok(value) => value,
+			case _ => fallback
+		};
+	}
+
+	class of(value) {
+		return value == nil ? Result.Err() : Result.Ok(value);
+	}
+}
+
+var ok = Result.Ok(42);
+print ok;
+print ok.value;
+print ok.isOk;
+print Result.Err();
+print Result.Err(message: "oops").message;
+print Result.of(nil).unwrap(0);
+print ok.unwrap(0);
+print Result.Ok(1) == Result.Ok(1);
+print Result.Ok(1) == Result.Ok(2);
+print Result.Ok(1) == Result.Err(1);
+print Result.Ok;
+print Result.values;
+`
+	assertPrograms(t, []testCase{
+		{program, "Result.Ok(42)\n42\ntrue\nResult.Err(failed)\noops\n0\n42\ntrue\nfalse\nfalse\n<variant Result.Ok>\n[<variant Result.Ok>, <variant Result.Err>]\n"},
+	})
+}
+
+func TestEnumMatching(t *testing.T) {
+	program := `
+enum Shape { Circle(radius), Rect(width, height), Empty }
+
+fun area(shape) {
+	return match (shape) {
+		case Shape.Circle(r) => 3 * r * r,
+		case Shape.Rect(w, h) => w * h,
+		case Shape.Empty => 0
+	};
+}
+
+print area(Shape.Circle(2));
+print area(Shape.Rect(2, 5));
+print area(Shape.Empty);
+`
+	assertPrograms(t, []testCase{
+		{program, "12\n10\n0\n"},
+	})
+}
+
+func TestEnumErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"enum Color { Red }\nColor.Red.x = 1;", "[line 2] Can't modify an enum or its values.\n"},
+		{"enum Color { Red }\nColor.Red = 1;", "[line 2] Can't modify an enum or its values.\n"},
+		{"enum Color { Red }\nprint Color.Blue;", "[line 2] Undefined variant 'Blue' of enum 'Color'.\n"},
+		{"enum Color { Red }\nprint Color.Red.x;", "[line 2] Undefined property 'x'.\n"},
+		{"enum Result { Ok(value) }\nResult.Ok();", "[line 2] Expected 1 arguments, but got 0.\n"},
+		{"enum Result { Ok(value) }\nmatch (Result.Ok(1)) { case Result.Ok(a, b) => nil }", "[line 2] Variant pattern expects at most 1 values of 'Result.Ok', but got 2.\n"},
+		{"enum Color {}", "[line 1] Error at '}': Expected at least one variant in enum body.\n"},
+		{"enum Color { Red, Red }", "[line 1] Variant 'Red' is already declared in enum 'Color'.\n"},
+		{"enum Color { Red; var x; }", "[line 1] Error at 'x': Enums can't declare fields.\n"},
+		{"enum Color { Red; set x(v) {} }", "[line 1] Error at 'x': Enums can't declare setters.\n"},
+		{"enum Color { Red; init() {} }", "[line 1] Enums can't include init() method.\n"},
+		{"enum Color { Red; f() { return super.f(); } }", "[line 1] Can't use 'super' in an enum.\n"},
+		{"enum Pair { Of([a, b]) }", "[line 1] Error at '[': Variant parameters can't be destructured.\n"},
+	})
+}
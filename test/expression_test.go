@@ -40,3 +40,11 @@ func TestBooleanExpressions(t *testing.T) {
 		{"29 > 31 ? false : 31 > 29 ? true : false", "true"},
 	})
 }
+
+func TestScannerErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print 1; @ 2;", "[line 1] Error: Unexpected character.\n"},
+		{"print 1 | 2;", "[line 1] Error: Unexpected character.\n"},
+		{"print \"open;\nprint 1;", "[line 2] Error: Unterminated string.\n"},
+	})
+}
@@ -0,0 +1,97 @@
+package test
+
+import "testing"
+
+func TestDeclaredFields(t *testing.T) {
+	program := `
+var created = 0;
+
+class Counter {
+	var count = 0;
+	var step = 1;
+	var label;
+	var #history = [];
+	class var total = 0;
+	class var instances = [];
+
+	init(step) {
+		this.step = step;
+		created = created + 1;
+	}
+
+	increment() {
+		this.count = this.count + this.step;
+		this.#history = append(this.#history, this.count);
+		Counter.total = Counter.total + 1;
+		return this;
+	}
+
+	history {
+		return this.#history;
+	}
+}
+
+var a = Counter(2);
+var b = Counter(5);
+a.increment().increment();
+b.increment();
+print a.count;
+print b.count;
+print a.label;
+print a.history;
+print b.history;
+print Counter.total;
+print created;
+`
+	assertPrograms(t, []testCase{
+		{program, "4\n5\nnil\n[2, 4]\n[5]\n3\n2\n"},
+	})
+}
+
+func TestInheritedAndTraitFields(t *testing.T) {
+	program := `
+trait Timestamped {
+	var createdAt = "now";
+	class var kind = "timestamped";
+}
+
+class Shape <> Timestamped {
+	var sides = 0;
+	var name = "shape";
+	var description = this.name + " with " + str(this.sides) + " sides";
+}
+
+class Square < Shape {
+	var sides = 4;
+	var copy = this.description;
+	class var unit = Square.kind + " square";
+}
+
+var square = Square();
+print square.sides;
+print square.description;
+print square.copy;
+print square.createdAt;
+print Square.unit;
+print inspect(Shape());
+`
+	assertPrograms(t, []testCase{
+		{program, "4\nshape with 0 sides\nshape with 0 sides\nnow\ntimestamped square\nShape { createdAt: \"now\", description: \"shape with 0 sides\", name: \"shape\", sides: 0 }\n"},
+	})
+}
+
+func TestDeclaredFieldErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"class A { var x = 1; var x = 2; }", "[line 1] Duplicate field 'x'.\n"},
+		{"class A { class var x; class var x; }", "[line 1] Duplicate field 'x'.\n"},
+		{"class A { var x; x() {} }", "[line 1] 'x' is declared both as a field and as a method.\n"},
+		{"class A { class var #x; }", "[line 1] Static field '#x' can't be private.\n"},
+		{"class A { class var x = this; }", "[line 1] Can't use 'this' inside static method, consider using 'className.property'.\n"},
+		{"class A { var x = 1 / 0; }\nA();", "[line 1] Division by zero is prohibited.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"class A { var x: Number = \"one\"; }", "[line 1] Type error: Can't assign String to field 'x' of type Number.\n"},
+		{"class A { var x: Number = 0; }\nA().x = \"one\";", "[line 2] Type error: Can't assign String to field 'x' of type Number.\n"},
+	})
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"fmt"
+	"glox/cmd"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Files under testdata describe what they should do in comments:
+//
+//	print 1 + 2; // expect: 3
+//	print nil + 1; // expect runtime error: Both operands should be numbers or strings.
+//	var unused = 1; // expect warning: Unused variable 'unused'.
+//	return 1; // expect error: Can't return from top-level code.
+//	print (1; // Error at ';': Expected ')' after expression.
+//	// [line 3] Error at end: Expected '}' after block.
+//
+// Output is expected in the order of the comments. Errors and warnings are expected on the line
+// of their comment, unless it gives another line like the last example. Scanner and parser errors
+// are written as they are reported, without the line.
+var (
+	expectedOutput       = regexp.MustCompile(`// expect: ?(.*)$`)
+	expectedRuntimeError = regexp.MustCompile(`// expect runtime error: (.+)$`)
+	expectedWarning      = regexp.MustCompile(`// expect warning: (.+)$`)
+	expectedError        = regexp.MustCompile(`// expect error: (.+)$`)
+	expectedSyntaxError  = regexp.MustCompile(`// (?:\[line (\d+)\] )?(Error.*)$`)
+
+	reportedErrors   = regexp.MustCompile(`(?s)\x1b\[31m(.*?)\x1b\[0m`)
+	reportedWarnings = regexp.MustCompile(`(?s)\x1b\[33m(.*?)\x1b\[0m`)
+)
+
+type goldenExpectations struct {
+	output   []string
+	errors   []string
+	warnings []string
+	exitCode int
+}
+
+func TestGoldenFiles(t *testing.T) {
+	err := filepath.WalkDir("testdata", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".glox" {
+			return err
+		}
+
+		t.Run(filepath.ToSlash(strings.TrimPrefix(path, "testdata"+string(filepath.Separator))), func(t *testing.T) {
+			runGoldenFile(t, path)
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runGoldenFile(t *testing.T, path string) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := parseExpectations(string(source))
+
+	output, exitCode := execute(string(source))
+	errors := reportedLines(reportedErrors, output)
+	warnings := reportedLines(reportedWarnings, output)
+	output = reportedWarnings.ReplaceAllString(reportedErrors.ReplaceAllString(output, ""), "")
+
+	if exitCode != expected.exitCode {
+		t.Errorf("Expected exit code %d, got %d.", expected.exitCode, exitCode)
+	}
+	assertLines(t, "output", expected.output, strings.Split(strings.TrimSuffix(output, "\n"), "\n"))
+	assertLines(t, "errors", expected.errors, errors)
+
+	// The resolver reports the warnings of a scope in no particular order.
+	slices.Sort(expected.warnings)
+	slices.Sort(warnings)
+	assertLines(t, "warnings", expected.warnings, warnings)
+}
+
+func parseExpectations(source string) goldenExpectations {
+	expected := goldenExpectations{output: make([]string, 0), errors: make([]string, 0), warnings: make([]string, 0)}
+
+	for idx, line := range strings.Split(source, "\n") {
+		lineNum := idx + 1
+
+		if match := expectedOutput.FindStringSubmatch(line); match != nil {
+			expected.output = append(expected.output, match[1])
+		} else if match := expectedRuntimeError.FindStringSubmatch(line); match != nil {
+			expected.errors = append(expected.errors, fmt.Sprintf("[line %d] %s", lineNum, match[1]))
+			expected.exitCode = 70
+		} else if match := expectedWarning.FindStringSubmatch(line); match != nil {
+			expected.warnings = append(expected.warnings, fmt.Sprintf("[line %d] Warning: %s", lineNum, match[1]))
+		} else if match := expectedError.FindStringSubmatch(line); match != nil {
+			expected.errors = append(expected.errors, fmt.Sprintf("[line %d] %s", lineNum, match[1]))
+			expected.exitCode = 67
+		} else if match := expectedSyntaxError.FindStringSubmatch(line); match != nil {
+			if match[1] != "" {
+				lineNum, _ = strconv.Atoi(match[1])
+			}
+			expected.errors = append(expected.errors, fmt.Sprintf("[line %d] %s", lineNum, match[2]))
+
+			// Only the scanner reports errors without a location.
+			expected.exitCode = 65
+			if strings.HasPrefix(match[2], "Error:") {
+				expected.exitCode = 63
+			}
+		}
+	}
+
+	return expected
+}
+
+func reportedLines(pattern *regexp.Regexp, output string) []string {
+	lines := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatch(output, -1) {
+		lines = append(lines, strings.TrimSuffix(match[1], "\n"))
+	}
+
+	return lines
+}
+
+func assertLines(t *testing.T, kind string, expected []string, got []string) {
+	if len(got) == 1 && got[0] == "" {
+		got = got[:0]
+	}
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("Expected %s:\n%s\nGot:\n%s", kind, strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+// execute runs source like "glox script.glox" does, returning everything it printed.
+func execute(source string) (string, int) {
+	var exitCode int
+	output := captureOutput(func() {
+		exitCode = cmd.Execute(source)
+	})
+
+	return output, exitCode
+}
@@ -0,0 +1,147 @@
+package test
+
+import "testing"
+
+func TestArrowLambdas(t *testing.T) {
+	program := `
+fun map(values, transform) {
+	var result = [];
+	for (var i = 0; i < len(values); i = i + 1) {
+		result = append(result, transform(values[i]));
+	}
+	return result;
+}
+
+var double = (x) => x * 2;
+var add = (a, b) => a + b;
+var greet = () => "hi";
+var scaled = (x: Number, factor = 3) => x * factor;
+var block = (x) => {
+	var y = x + 1;
+	return y * 2;
+};
+
+print double(4);
+print add(1, 2);
+print greet();
+print scaled(2);
+print block(1);
+print map([1, 2, 3], (x) => x * x);
+print (1 + 2) * 3;
+print match (3) {
+	case x if (x > 1) => "big",
+	case _ => "small"
+};
+`
+	assertPrograms(t, []testCase{
+		{program, "8\n3\nhi\n6\n4\n[1, 4, 9]\n9\nbig\n"},
+	})
+}
+
+func TestArrowLambdasInGuards(t *testing.T) {
+	program := `
+fun any(values, test) {
+	for (var i = 0; i < len(values); i = i + 1) {
+		if (test(values[i])) {
+			return true;
+		}
+	}
+	return false;
+}
+
+fun find(n) {
+	return match (n) {
+		case n if any([1, 2], (y) => y == n) => "hit",
+		case _ => "miss"
+	};
+}
+
+fun sign(n) {
+	return match (n) {
+		case n if match (n) { case m if m == 0 => false, case _ => true } and (n > 0) => "positive",
+		case _ => "other"
+	};
+}
+
+print find(2);
+print find(3);
+print sign(4);
+print sign(0);
+print sign(-4);
+`
+	assertPrograms(t, []testCase{
+		{program, "hit\nmiss\npositive\nother\nother\n"},
+	})
+}
+
+func TestPipeline(t *testing.T) {
+	program := `
+fun map(values, transform) {
+	var result = [];
+	for (var i = 0; i < len(values); i = i + 1) {
+		result = append(result, transform(values[i]));
+	}
+	return result;
+}
+
+fun sum(values) {
+	var total = 0;
+	for (var i = 0; i < len(values); i = i + 1) {
+		total = total + values[i];
+	}
+	return total;
+}
+
+var double = (x) => x * 2;
+
+print 3 |> double;
+print 3 |> double |> str;
+print [1, 2, 3] |> map((x) => x + 1) |> sum;
+print 2 |> ((x) => x * 10);
+print nil ?? 1 |> double;
+`
+	assertPrograms(t, []testCase{
+		{program, "6\n6\n9\n20\n2\n"},
+	})
+}
+
+func TestBind(t *testing.T) {
+	program := `
+fun add(a, b, c = 0) {
+	return a + b + c;
+}
+
+class Multiplier {
+	init(factor) {
+		this.factor = factor;
+	}
+
+	__call__(x) {
+		return x * this.factor;
+	}
+}
+
+var addOne = bind(add, 1);
+print addOne(2);
+print addOne(2, 3);
+print bind(add, 1, 2)();
+print bind(add)(1, 2);
+print bind(Multiplier(3), 2)();
+print bind((a, b) => a - b, 10)(4);
+print addOne;
+`
+	assertPrograms(t, []testCase{
+		{program, "3\n6\n3\n3\n6\n6\n<partial fn>\n"},
+	})
+}
+
+func TestLambdaErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print bind(1);", "[line 1] First argument to 'bind' should be callable.\n"},
+		{"fun f(a) {}\nbind(f, 1, 2);", "[line 2] Can't bind 2 arguments to a function that takes at most 1.\n"},
+		{"fun f(a, b) {}\nbind(f, 1)();", "[line 2] Expected 1 arguments, but got 0.\n"},
+		{"fun f(a, b) {}\nbind(f, 1)(b: 2);", "[line 2] Partially applied functions don't accept named arguments.\n"},
+		{"print 1 |> 2;", "[line 1] Non callable object, can only call functions and classes.\n"},
+		{"var f = (x) => ;", "[line 1] Error at ';': Expected an expression.\n"},
+	})
+}
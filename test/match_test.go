@@ -0,0 +1,116 @@
+package test
+
+import "testing"
+
+func TestMatchLiterals(t *testing.T) {
+	program := `
+fun describe(value) {
+	return match (value) {
+		case 0 => "zero",
+		case -1 => "minus one",
+		case "hello" => "greeting",
+		case true => "yes",
+		case nil => "nothing",
+		case 1..9 => "digit",
+		case 10..<100 => "two digits",
+		case n if n < 0 => "negative " + str(n),
+		case _ => "something else",
+	};
+}
+
+print describe(0);
+print describe(-1);
+print describe("hello");
+print describe(true);
+print describe(nil);
+print describe(9);
+print describe(99);
+print describe(100);
+print describe(-7);
+`
+	assertPrograms(t, []testCase{
+		{program, "zero\nminus one\ngreeting\nyes\nnothing\ndigit\ntwo digits\nsomething else\nnegative -7\n"},
+	})
+}
+
+func TestMatchStructures(t *testing.T) {
+	program1 := `
+fun sum(values) {
+	return match (values) {
+		case [] => 0
+		case [head, ...tail] => head + sum(tail)
+	};
+}
+
+fun shape(values) {
+	return match (values) {
+		case [_] => "one"
+		case [1, second] => "starts with 1 then " + str(second)
+		case [_, _, ...rest] => "many, rest " + str(rest)
+	};
+}
+
+print sum([1, 2, 3, 4]);
+print shape([5]);
+print shape([1, 7]);
+print shape([2, 3, 4, 5]);
+`
+
+	program2 := `
+class Point {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+}
+
+class Point3D < Point {
+	init(x, y, z) {
+		super.init(x, y);
+		this.z = z;
+	}
+}
+
+fun where(point) {
+	match (point) {
+		case Point3D(0, 0, 0) => {
+			print "3D origin";
+		}
+		case Point(0, 0) => {
+			print "origin";
+		}
+		case Point(x, 0) => {
+			print "on x axis at " + str(x);
+		}
+		case Point(x, y) if x == y => {
+			print "diagonal";
+		}
+		case Point(x, y) => {
+			print str(x) + ", " + str(y);
+		}
+	}
+}
+
+where(Point3D(0, 0, 0));
+where(Point(0, 0));
+where(Point(3, 0));
+where(Point3D(2, 2, 5));
+where(Point(1, 2));
+`
+	assertPrograms(t, []testCase{
+		{program1, "10\none\nstarts with 1 then 7\nmany, rest [4, 5]\n"},
+		{program2, "3D origin\norigin\non x axis at 3\ndiagonal\n1, 2\n"},
+	})
+}
+
+func TestMatchErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print match (3) { case 1 => \"one\" case 2 => \"two\" };", "[line 1] No match case for value '3'.\n"},
+		{"class A { init(x) { this.x = x; } }\nprint match (A(1)) { case A(a, b) => a };", "[line 2] Class pattern expects at most 1 fields of 'A', but got 2.\n"},
+	})
+
+	assertWarnings(t, []testCase{
+		{"print match (1) { case 1 => 1 case 1 => 2 case _ => 3 };", "[line 1] Warning: Unreachable match case.\n"},
+		{"{ var v = 2; print match (v) { case x => x case 2 => 2 }; }", "[line 1] Warning: Unreachable match case.\n"},
+	})
+}
@@ -0,0 +1,135 @@
+package test
+
+import "testing"
+
+func TestArithmeticOperatorOverloading(t *testing.T) {
+	program := `
+class Vector {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+
+	__add__(other) {
+		return Vector(this.x + other.x, this.y + other.y);
+	}
+
+	__sub__(other) {
+		return Vector(this.x - other.x, this.y - other.y);
+	}
+
+	__mul__(scalar) {
+		return Vector(this.x * scalar, this.y * scalar);
+	}
+
+	__rmul__(scalar) {
+		return this * scalar;
+	}
+
+	__neg__() {
+		return Vector(-this.x, -this.y);
+	}
+
+	__eq__(other) {
+		return other.x == this.x and other.y == this.y;
+	}
+
+	__lt__(other) {
+		return this.x * this.x + this.y * this.y < other.x * other.x + other.y * other.y;
+	}
+
+	show {
+		return "(" + str(this.x) + ", " + str(this.y) + ")";
+	}
+}
+
+var a = Vector(1, 2);
+var b = Vector(3, 4);
+print (a + b).show;
+print (b - a).show;
+print (a * 3).show;
+print (2 * a).show;
+print (-a).show;
+print a == Vector(1, 2);
+print a != Vector(1, 2);
+print a == b;
+print a < b;
+print b > a;
+`
+	assertPrograms(t, []testCase{
+		{program, "(4, 6)\n(2, 2)\n(3, 6)\n(2, 4)\n(-1, -2)\ntrue\nfalse\nfalse\ntrue\ntrue\n"},
+	})
+}
+
+func TestIndexAndCallOverloading(t *testing.T) {
+	program := `
+trait Money {
+	__add__(other) {
+		return this.with(this.cents + other.cents);
+	}
+}
+
+class Dollars <> Money {
+	init(cents) {
+		this.cents = cents;
+	}
+
+	with(cents) {
+		return Dollars(cents);
+	}
+}
+
+class Grid {
+	init() {
+		this.cells = [0, 0, 0];
+	}
+
+	__index__(index) {
+		return this.cells[index];
+	}
+
+	__setindex__(index, value) {
+		this.cells[index] = value * 10;
+	}
+}
+
+class Multiplier {
+	init(factor) {
+		this.factor = factor;
+	}
+
+	__call__(value, offset = 0) {
+		return value * this.factor + offset;
+	}
+}
+
+print (Dollars(150) + Dollars(275)).cents;
+
+var grid = Grid();
+grid[1] = 4;
+print grid[1];
+print grid.cells;
+
+var triple = Multiplier(3);
+print triple(5);
+print triple(5, offset: 1);
+`
+	assertPrograms(t, []testCase{
+		{program, "425\n40\n[0, 40, 0]\n15\n16\n"},
+	})
+}
+
+func TestOperatorOverloadingErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"class A {}\nprint A() + 1;", "[line 2] Both operands should be numbers or strings.\n"},
+		{"class A { __add__() { return 1; } }\nprint A() + 1;", "[line 2] Special method '__add__' should accept 1 arguments.\n"},
+		{"class A {}\nA()();", "[line 2] Non callable object, can only call functions and classes.\n"},
+		{"class A {}\nprint -A();", "[line 2] Operand must be a number.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"class A { __add__(other): Number { return 1; } }\nvar s: String = A() + A();", "[line 2] Type error: Can't assign Number to variable 's' of type String.\n"},
+		{"class A {}\nA() + 1;", "[line 2] Type error: Both operands should be numbers or strings, got A and Number.\n"},
+		{"class A { __call__(a: Number) {} }\nA()(\"one\");", "[line 2] Type error: Argument 1 of '__call__' expects Number, got String.\n"},
+	})
+}
@@ -0,0 +1,80 @@
+package test
+
+import "testing"
+
+func TestNullCoalescing(t *testing.T) {
+	assertExpressions(t, []testCase{
+		{"nil ?? 1", "1"},
+		{"0 ?? 1", "0"},
+		{"false ?? true", "false"},
+		{"nil ?? nil ?? 3", "3"},
+		{"nil ?? 1 == 1", "true"},
+		{"true ? nil ?? 2 : 3", "2"},
+	})
+}
+
+func TestOptionalChaining(t *testing.T) {
+	program := `
+class Node {
+	init(value, next) {
+		this.value = value;
+		this.next = next;
+	}
+
+	describe() {
+		return "node " + str(this.value);
+	}
+}
+
+var list = Node(1, Node(2, nil));
+var missing = nil;
+
+print list?.next?.value;
+print list.next.next?.value;
+print list.next.next?.next.value;
+print missing?.describe();
+print list?.describe();
+print missing?.(1, 2);
+print list.describe?.();
+
+var values = [1, 2, 3];
+var none = nil;
+print values?[1];
+print none?[0];
+print none?[0].value;
+print true ?[1] : [2];
+print missing?.value ?? "default";
+`
+	assertPrograms(t, []testCase{
+		{program, "2\nnil\nnil\nnil\nnode 1\nnil\nnode 1\n2\nnil\nnil\n[1]\ndefault\n"},
+	})
+}
+
+func TestOptionalIndexOrTernary(t *testing.T) {
+	assertPrograms(t, []testCase{
+		{"var a = true;\nprint a?[1]:[2];", "[1]\n"},
+		{"var a = false;\nprint a?[1]:[2];", "[2]\n"},
+		{"var a = [1, 2];\nprint a?[1];", "2\n"},
+		{"var a = nil;\nvar b = [3];\nprint b ? a?[0] : 1;", "nil\n"},
+		{"var a = [1];\nprint a ? a?[0]:[2] : 3;", "[0]\n"},
+		{"var a = [1];\nprint a?[0] ? [2] : [3];", "[2]\n"},
+		{"var a = true;\nvar b = nil;\nprint a?[1]:[2] + b?[0];", "[1]\n"},
+		{"var a = true;\nprint [a?[1]:[2], a?[3]:[4]];", "[[1], [3]]\n"},
+	})
+}
+
+func TestOptionalChainingErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"var a = nil;\nprint a?.b.c;\nprint a.b;", "[line 3] Only instances have properties.\n"},
+		{"class A {}\nprint A()?.b;", "[line 2] Undefined property 'b'.\n"},
+		{"var a = 1;\nprint a?.();", "[line 2] Non callable object, can only call functions and classes.\n"},
+		{"var a = nil;\na?.b = 1;", "[line 2] Error at '=': Invalid assignment target.\n"},
+		{"var a = nil;\nprint a?.#b;", "[line 2] Error at '#b': Expected property name after '?.'.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"var a: Number? = nil;\nvar b: String = a ?? 1;", "[line 2] Type error: Can't assign Number to variable 'b' of type String.\n"},
+		{"print nil?.a;\nprint nil?[0];\nprint nil?.();", ""},
+		{"print 1?.a;", "[line 1] Type error: Only instances have properties, got Number.\n"},
+	})
+}
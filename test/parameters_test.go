@@ -0,0 +1,109 @@
+package test
+
+import "testing"
+
+func TestDefaultParameters(t *testing.T) {
+	program := `
+fun greet(name, greeting = "Hello", punctuation = greeting == "Hello" ? "!" : ".") {
+	return greeting + ", " + name + punctuation;
+}
+print greet("Ann");
+print greet("Bob", "Bye");
+print greet("Eve", "Hi", "?");
+
+var counter = 0;
+fun next(step = counter + 1) {
+	counter = step;
+	return counter;
+}
+print next();
+print next();
+print next(10);
+
+class Point {
+	init(x = 0, y = x) {
+		this.x = x;
+		this.y = y;
+	}
+}
+var point = Point(3);
+print str(point.x) + ", " + str(point.y);
+`
+	assertPrograms(t, []testCase{
+		{program, "Hello, Ann!\nBye, Bob.\nHi, Eve?\n1\n2\n10\n3, 3\n"},
+	})
+}
+
+func TestRestParametersAndSpreadArguments(t *testing.T) {
+	program := `
+fun sum(first, ...rest) {
+	var total = first;
+	for (var i = 0; i < len(rest); i = i + 1) {
+		total = total + rest[i];
+	}
+	return total;
+}
+print sum(1);
+print sum(1, 2, 3);
+
+var numbers = [4, 5, 6];
+print sum(...numbers);
+print sum(0, ...numbers, 10);
+
+var collect = fun (...values) {
+	return values;
+};
+print collect();
+print collect(1, ...[2, 3]);
+`
+	assertPrograms(t, []testCase{
+		{program, "1\n6\n15\n25\n[]\n[1, 2, 3]\n"},
+	})
+}
+
+func TestNamedArguments(t *testing.T) {
+	program := `
+fun describe(name, age = 0, city = "unknown") {
+	return name + " " + str(age) + " " + city;
+}
+print describe("Ann", city: "Oslo");
+print describe(city: "Rome", name: "Bob");
+print describe("Eve", 30, city: "Paris");
+
+class Box {
+	init(width, height = 1) {
+		this.area = width * height;
+	}
+}
+print Box(height: 3, width: 2).area;
+`
+	assertPrograms(t, []testCase{
+		{program, "Ann 0 Oslo\nBob 0 Rome\nEve 30 Paris\n6\n"},
+	})
+}
+
+func TestParameterErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"fun f(a, b = 1) {}\nf();", "[line 2] Expected at least 1 arguments, but got 0.\n"},
+		{"fun f(a, b = 1) {}\nf(1, 2, 3);", "[line 2] Expected at most 2 arguments, but got 3.\n"},
+		{"fun f(a, ...rest) {}\nf();", "[line 2] Expected at least 1 arguments, but got 0.\n"},
+		{"fun f(a, b) {}\nf(...[1, 2, 3]);", "[line 2] Expected 2 arguments, but got 3.\n"},
+		{"fun f(a) {}\nf(...1);", "[line 2] Only arrays can be spread.\n"},
+		{"fun f(a) {}\nf(b: 1);", "[line 2] Unknown parameter 'b'.\n"},
+		{"fun f(a) {}\nf(1, a: 1);", "[line 2] Argument for parameter 'a' was passed more than once.\n"},
+		{"fun f(a, b = 2) {}\nf(b: 1);", "[line 2] Missing argument for parameter 'a'.\n"},
+		{"fun f(...rest) {}\nf(rest: 1);", "[line 2] Unknown parameter 'rest'.\n"},
+		{"len(array: []);", "[line 1] Native functions don't accept named arguments.\n"},
+		{"fun f(a = 1, b) {}", "[line 1] Error at 'b': Parameter without a default value can't follow one with a default value.\n"},
+		{"fun f(...rest, a) {}", "[line 1] Error at ',': Rest parameter must be the last parameter.\n"},
+		{"fun f(a, b) {}\nf(a: 1, 2);", "[line 2] Error at '2': Positional arguments can't follow named arguments.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"fun f(a: Number, b: String = \"\") {}\nf(1, 2);", "[line 2] Type error: Argument 2 of 'f' expects String, got Number.\n"},
+		{"fun f(a: Number = \"zero\") {}", "[line 1] Type error: Default value of parameter 'a' expects Number, got String.\n"},
+		{"fun f(a, b = 1) {}\nf(1, 2, 3);", "[line 2] Type error: Expected at most 2 arguments, but got 3.\n"},
+		{"fun f(a: Number) {}\nf(a: \"one\");", "[line 2] Type error: Argument 'a' of 'f' expects Number, got String.\n"},
+		{"fun f(a) {}\nf(b: 1);", "[line 2] Type error: Unknown parameter 'b'.\n"},
+	})
+}
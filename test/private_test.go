@@ -0,0 +1,92 @@
+package test
+
+import "testing"
+
+func TestPrivateMembers(t *testing.T) {
+	program := `
+class Account {
+	init(balance) {
+		this.#balance = balance;
+	}
+
+	deposit(amount) {
+		this.#validate(amount);
+		this.#balance = this.#balance + amount;
+		return this;
+	}
+
+	balance {
+		return this.#balance;
+	}
+
+	#validate(amount) {
+		if (amount <= 0) {
+			print "invalid amount";
+		}
+	}
+
+	#doubled {
+		return this.#balance * 2;
+	}
+
+	report() {
+		var describe = fun () {
+			return "balance " + str(this.#balance) + ", doubled " + str(this.#doubled);
+		};
+		return describe();
+	}
+}
+
+var account = Account(10);
+account.deposit(5).deposit(-1);
+print account.balance;
+print account.report();
+print inspect(account);
+`
+	assertPrograms(t, []testCase{
+		{program, "invalid amount\n14\nbalance 14, doubled 28\nAccount {}\n"},
+	})
+}
+
+func TestPrivateMembersAreKeptPerClass(t *testing.T) {
+	program := `
+class Base {
+	init() {
+		this.#id = "base";
+	}
+
+	baseId() {
+		return this.#id;
+	}
+}
+
+class Derived < Base {
+	init() {
+		super.init();
+		this.#id = "derived";
+	}
+
+	derivedId() {
+		return this.#id;
+	}
+}
+
+var derived = Derived();
+print derived.baseId();
+print derived.derivedId();
+`
+	assertPrograms(t, []testCase{
+		{program, "base\nderived\n"},
+	})
+}
+
+func TestPrivateMemberErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"class A { init() { this.#x = 1; } }\nprint A().#x;", "[line 2] Can't access private member '#x' outside of a class.\n"},
+		{"class A { same(other) { return other.#x; } }", "[line 1] Private member '#x' can only be accessed through 'this'.\n"},
+		{"class A { class #create() {} }", "[line 1] Static method '#create' can't be private.\n"},
+		{"class A { read() { return this.#missing; } }\nA().read();", "[line 1] Undefined private member '#missing' in class 'A'.\n"},
+		{"class A { #secret() { return 1; } }\nclass B < A { read() { return this.#secret(); } }\nB().read();", "[line 2] Undefined private member '#secret' in class 'B'.\n"},
+		{"var x = #;", "[line 1] Error: Expected a member name after '#'.\n"},
+	})
+}
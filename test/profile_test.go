@@ -0,0 +1,117 @@
+package test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"glox/interpreter"
+	"glox/parser"
+	"glox/profile"
+	"glox/resolver"
+	"glox/scanner"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestProfiler(t *testing.T) {
+	source := `fun fib(n) {
+	if (n < 2) return n;
+	return fib(n - 1) + fib(n - 2);
+}
+
+class Greeter {
+	greet() {
+		return "hello";
+	}
+}
+
+var greeter = Greeter();
+for (var i = 0; i < 3; i = i + 1) {
+	greeter.greet();
+}
+print fib(5);`
+
+	profiler, output := profileProgram(t, source)
+	if output != "5\n" {
+		t.Fatalf("Expected the program to print 5, got %q.", output)
+	}
+
+	var report bytes.Buffer
+	if err := profile.WriteReport(&report, profiler); err != nil {
+		t.Fatal(err)
+	}
+	for _, pattern := range []string{
+		`Flat profile:`,
+		`Cumulative profile:`,
+		`\s15\s+fib \(line 1\)\n`,
+		`\s3\s+Greeter\.greet \(line 7\)\n`,
+		`\s1\s+main\n`,
+		`\s3\s+7\s+\S+\s+\S+\s+return fib\(n - 1\) \+ fib\(n - 2\);\n`,
+	} {
+		if !regexp.MustCompile(pattern).MatchString(report.String()) {
+			t.Fatalf("Expected the report to match %q, got:\n%s", pattern, report.String())
+		}
+	}
+
+	var folded bytes.Buffer
+	if err := profile.WriteFolded(&folded, profiler); err != nil {
+		t.Fatal(err)
+	}
+	stacks := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(folded.String()), "\n") {
+		stacks = append(stacks, line[:strings.LastIndex(line, " ")])
+	}
+	expected := []string{"main", "main;Greeter.greet", "main;fib", "main;fib;fib", "main;fib;fib;fib", "main;fib;fib;fib;fib", "main;fib;fib;fib;fib;fib"}
+	if strings.Join(stacks, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Expected the stacks:\n%s\nGot:\n%s", strings.Join(expected, "\n"), folded.String())
+	}
+
+	var pprof bytes.Buffer
+	if err := profile.WritePprof(&pprof, profiler); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := gzip.NewReader(&pprof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"fib", "Greeter.greet", "nanoseconds", "profile.glox"} {
+		if !bytes.Contains(decoded, []byte(name)) {
+			t.Fatalf("Expected the pprof profile to contain %q.", name)
+		}
+	}
+}
+
+func profileProgram(t *testing.T, source string) (*profile.Profiler, string) {
+	tokens, err := scanner.New(source).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements, errs := parser.New(tokens).Parse()
+	if len(errs) != 0 {
+		t.Fatal(errs[0])
+	}
+
+	_interpreter := interpreter.New()
+	if _, err := resolver.New(_interpreter).Resolve(statements); err != nil {
+		t.Fatal(err)
+	}
+
+	profiler := profile.New("profile.glox", source)
+	_interpreter.SetTracer(profiler)
+
+	output := captureOutput(func() {
+		err = _interpreter.Interpret(statements)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiler.Stop()
+
+	return profiler, output
+}
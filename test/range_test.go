@@ -0,0 +1,86 @@
+package test
+
+import "testing"
+
+func TestRanges(t *testing.T) {
+	assertExpressions(t, []testCase{
+		{"0..5", "0..5"},
+		{"0..<5", "0..<5"},
+		{"0..10 step 2", "0..10 step 2"},
+		{"len(0..5)", "6"},
+		{"len(0..<5)", "5"},
+		{"len(0..<10 step 3)", "4"},
+		{"len(5..1)", "0"},
+		{"len(5..1 step -1)", "5"},
+		{"len(0..9007199254740991)", "9.007199254740992e+15"},
+		{"[...0..<4]", "[0, 1, 2, 3]"},
+		{"[...0..1 step 0.25]", "[0, 0.25, 0.5, 0.75, 1]"},
+		{"(1..3)[2]", "3"},
+		{"append(0..<2, 2)", "[0, 1, 2]"},
+		{"1 + 1..2 * 3", "2..6"},
+		{"0..5 == 0..5", "true"},
+		{"0..5 == 0..<5", "false"},
+		{"type(0..1)", "range"},
+	})
+
+	program := `
+var n = 3;
+var [first, second] = 0..<2;
+print first + second;
+print [...0..n - 1];
+
+fun sum(a, b, c) {
+	return a + b + c;
+}
+print sum(...1..3);
+`
+	assertPrograms(t, []testCase{
+		{program, "1\n[0, 1, 2]\n6\n"},
+	})
+}
+
+func TestComprehensions(t *testing.T) {
+	program := `
+var values = [1, 2, 3, 4];
+print [x * x for x in values];
+print [x for x in 0..<10 if x % 3 == 0];
+print [[x, y] for x in 1..2];
+
+var x = "outer";
+var doubled = [x * 2 for x in values if x > 2];
+print doubled;
+print x;
+
+var callbacks = [() => i for i in 0..<3];
+print callbacks[0]() + callbacks[2]();
+print [i for i in []];
+`
+	assertPrograms(t, []testCase{
+		{"var y = 10;\n" + program, "[1, 4, 9, 16]\n[0, 3, 6, 9]\n[[1, 10], [2, 10]]\n[6, 8]\nouter\n2\n[]\n"},
+	})
+}
+
+func TestRangeErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print 0..5 step 0;", "[line 1] Range step can't be zero.\n"},
+		{"print 0..\"a\";", "[line 1] Range bounds and step should be numbers.\n"},
+		{"print (0..5)[6];", "[line 1] Array index is out of bounds.\n"},
+		{"var r = 0..5;\nr[0] = 1;", "[line 2] Can't modify a range.\n"},
+		{"print [x for x in 1];", "[line 1] Only arrays, ranges and enums can be iterated.\n"},
+		{"print [x for x of [1]];", "[line 1] Error at 'of': Expected 'in' after comprehension variable.\n"},
+		{"print [x for x in [1]];\nprint x;", "[line 2] Undefined variable 'x'.\n"},
+		{"var a = [...0..10000000000000000000];", "[line 1] Ranges can have at most 9007199254740992 elements.\n"},
+		{"print len(0..10000000000000000000000);", "[line 1] Ranges can have at most 9007199254740992 elements.\n"},
+		{"print [x for x in 0..100000000000000000000];", "[line 1] Ranges can have at most 9007199254740992 elements.\n"},
+		{"print [...0..100000000];", "[line 1] Only ranges of at most 16777216 elements can be turned into arrays.\n"},
+		{"var [a] = 0..100000000;", "[line 1] Only ranges of at most 16777216 elements can be turned into arrays.\n"},
+		{"var big = 100000000000000000000;\nbig = big * big * big * big * big * big * big * big * big * big * big * big * big * big * big * big;\nprint 0..big;", "[line 3] Range bounds and step should be finite.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"var a: Array = 0..3;\nprint len(0..<3);\nprint [...0..3];", ""},
+		{"print 0..\"a\";", "[line 1] Type error: Range bounds and step should be numbers, got String.\n"},
+		{"print [x for x in 1];", "[line 1] Type error: Only arrays, ranges and enums can be iterated, got Number.\n"},
+		{"print [x + \"a\" for x in 0..3];", "[line 1] Type error: Both operands should be numbers or strings, got Number and String.\n"},
+	})
+}
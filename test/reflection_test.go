@@ -0,0 +1,111 @@
+package test
+
+import "testing"
+
+func TestIsOperator(t *testing.T) {
+	program := `
+trait Named {}
+trait Loud <> Named {}
+
+class Animal <> Named {}
+class Dog < Animal <> Loud {}
+class Rock {}
+
+var dog = Dog();
+print dog is Dog;
+print dog is Animal;
+print dog is Rock;
+print dog is Named;
+print dog is Loud;
+print Animal() is Loud;
+print Rock() is Named;
+print 1 is Dog;
+print Dog is Dog;
+`
+	assertPrograms(t, []testCase{
+		{program, "true\ntrue\nfalse\ntrue\ntrue\nfalse\nfalse\nfalse\nfalse\n"},
+	})
+}
+
+func TestReflection(t *testing.T) {
+	program := `
+trait Greets {
+	greet() {
+		return "hi";
+	}
+}
+
+class Base {
+	init() {
+		this.id = 1;
+	}
+
+	describe() {
+		return "base";
+	}
+
+	kind {
+		return "base";
+	}
+
+	#secret() {
+		return nil;
+	}
+
+	class create() {
+		return Base();
+	}
+}
+
+class Point < Base <> Greets {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+
+	length {
+		return 0;
+	}
+
+	class origin() {
+		return Point(0, 0);
+	}
+}
+
+print type(nil);
+print type(true);
+print type(1);
+print type("a");
+print type(1n);
+print type(1.5d);
+print type([]);
+print type(clock);
+print type(fun () {});
+print type(Point);
+print type(Greets);
+print type(Point(1, 2));
+print fields(Point(1, 2));
+print methods(Point);
+print getters(Point);
+print staticMethods(Point);
+print superclass(Point);
+print superclass(Base);
+print traits(Point);
+print traits(Base);
+`
+	assertPrograms(t, []testCase{
+		{program, "nil\nboolean\nnumber\nstring\nbigint\ndecimal\narray\nfunction\nfunction\nclass\ntrait\nPoint\n[x, y]\n[describe, greet, init]\n[kind, length]\n[create, origin]\n<class Base>\nnil\n[<trait Greets>]\n[]\n"},
+	})
+}
+
+func TestReflectionErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"print 1 is 2;", "[line 1] Right operand of 'is' should be a class, a trait or an enum.\n"},
+		{"print fields(1);", "[line 1] Argument to 'fields' should be an instance.\n"},
+		{"class A {}\nprint methods(A());", "[line 2] Argument to 'methods' should be a class.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"print 1 is \"a\";", "[line 1] Type error: Right operand of 'is' should be a class, a trait or an enum, got String.\n"},
+	})
+}
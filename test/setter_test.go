@@ -0,0 +1,100 @@
+package test
+
+import "testing"
+
+func TestSetters(t *testing.T) {
+	program := `
+class Square {
+	init(side) {
+		this.side = side;
+	}
+
+	area {
+		return this.side * this.side;
+	}
+
+	set area(value) {
+		this.side = value / 4;
+	}
+}
+
+var square = Square(2);
+print square.area;
+square.area = 64;
+print square.side;
+print square.area = 36;
+print square.side;
+
+class Temperature {
+	class set fahrenheit(value) {
+		Temperature.celsius = (value - 32) * 5 / 9;
+	}
+}
+Temperature.fahrenheit = 212;
+print Temperature.celsius;
+`
+	assertPrograms(t, []testCase{
+		{program, "4\n16\n36\n9\n100\n"},
+	})
+}
+
+func TestInheritedAndTraitSetters(t *testing.T) {
+	program := `
+trait Logged {
+	set value(value) {
+		this.log = append(this.log, value);
+		this.current = value;
+	}
+}
+
+class Base <> Logged {
+	init() {
+		this.log = [];
+	}
+}
+
+class Derived < Base {
+	init() {
+		super.init();
+	}
+}
+
+var derived = Derived();
+derived.value = 1;
+derived.value = 2;
+print derived.log;
+print derived.current;
+
+class Point {
+	set x(value) {
+		this.coordinates = "x=" + str(value);
+	}
+
+	set(key, value) {
+		return key + "=" + str(value);
+	}
+}
+
+var point = Point();
+[point.x, point.y] = [3, 4];
+print point.coordinates;
+print point.y;
+print point.set("z", 5);
+`
+	assertPrograms(t, []testCase{
+		{program, "[1, 2]\n2\nx=3\n4\nz=5\n"},
+	})
+}
+
+func TestSetterErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"class A { set b() {} }", "[line 1] Setter 'b' must have exactly one parameter.\n"},
+		{"class A { set b(x, y) {} }", "[line 1] Setter 'b' must have exactly one parameter.\n"},
+		{"trait T { class set b {} }", "[line 1] Setter 'b' must have exactly one parameter.\n"},
+		{"class A { set b(value) { this.missing.x = value; } }\nA().b = 1;", "[line 1] Undefined property 'missing'.\n"},
+	})
+
+	assertTypeErrors(t, []testCase{
+		{"class A { set b(value: Number) {} }\nA().b = \"one\";", "[line 2] Type error: Can't assign String to property 'b' of type Number.\n"},
+	})
+}
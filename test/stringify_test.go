@@ -0,0 +1,106 @@
+package test
+
+import "testing"
+
+func TestToString(t *testing.T) {
+	program := `
+trait Named {
+	toString() {
+		return "<" + this.name + ">";
+	}
+}
+
+class Point {
+	init(x, y) {
+		this.x = x;
+		this.y = y;
+	}
+
+	toString() {
+		return "Point(" + str(this.x) + ", " + str(this.y) + ")";
+	}
+}
+
+class User <> Named {
+	init(name) {
+		this.name = name;
+	}
+}
+
+class Plain {}
+
+var point = Point(1, 2);
+print point;
+print str(point) + "!";
+print [point, User("ann")];
+print Plain();
+`
+	assertPrograms(t, []testCase{
+		{program, "Point(1, 2)\nPoint(1, 2)!\n[Point(1, 2), <ann>]\n<Plain instance>\n"},
+	})
+}
+
+func TestToStringFallbacks(t *testing.T) {
+	program := `
+class Recursive {
+	toString() {
+		return "recursive " + str(this);
+	}
+}
+
+class NeedsArgument {
+	toString(format) {
+		return format;
+	}
+}
+
+print Recursive();
+print NeedsArgument();
+`
+	assertPrograms(t, []testCase{
+		{program, "recursive <Recursive instance>\n<NeedsArgument instance>\n"},
+	})
+}
+
+func TestToStringErrors(t *testing.T) {
+	broken := "class Broken {\n\ttoString() {\n\t\treturn this.missing;\n\t}\n}\n"
+	testFailingPrograms(t, []testCase{
+		{broken + "print Broken();", "[line 3] Undefined property 'missing'.\n"},
+		{broken + "print str(Broken());", "[line 3] Undefined property 'missing'.\n"},
+		{broken + "print [1, Broken()];", "[line 3] Undefined property 'missing'.\n"},
+		{"class NotAString {\n\ttoString() {\n\t\treturn 42;\n\t}\n}\nprint NotAString();", "[line 2] toString() should return a string.\n"},
+	})
+}
+
+func TestInspect(t *testing.T) {
+	program := `
+class Node {
+	init(value) {
+		this.value = value;
+		this.children = [];
+	}
+
+	toString() {
+		return "node";
+	}
+}
+
+class Empty {}
+
+var root = Node("root");
+var child = Node(1);
+root.children = append(root.children, child);
+child.parent = root;
+
+print inspect(Empty());
+print inspect(child);
+print inspect(root);
+print inspect([1, "two", nil]);
+`
+	assertPrograms(t, []testCase{
+		{program, "Empty {}\n" +
+			"Node { children: [], parent: Node { children: [<cycle Node>], value: \"root\" }, value: 1 }\n" +
+			"Node { children: [Node { children: [], parent: <cycle Node>, value: 1 }], value: \"root\" }\n" +
+			"[1, \"two\", nil]\n"},
+	})
+}
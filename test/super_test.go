@@ -0,0 +1,104 @@
+package test
+
+import "testing"
+
+func TestStaticSuper(t *testing.T) {
+	program := `
+class Model {
+	class var table = "models";
+
+	class create() {
+		return "create " + this_name();
+	}
+
+	class label {
+		return "Model";
+	}
+}
+
+fun this_name() {
+	return "record";
+}
+
+class User < Model {
+	class var table = "users, " + super.table;
+
+	class create() {
+		return super.create() + " as user";
+	}
+
+	class label {
+		return "User < " + super.label;
+	}
+}
+
+fun scoped() {
+	var prefix = "scoped";
+
+	class Local {
+		class describe() {
+			return prefix;
+		}
+	}
+
+	return Local.describe();
+}
+
+print User.create();
+print User.label;
+print User.table;
+print scoped();
+`
+	assertPrograms(t, []testCase{
+		{program, "create record as user\nUser < Model\nusers, models\nscoped\n"},
+	})
+}
+
+func TestTraitSuper(t *testing.T) {
+	program := `
+class Store {
+	save(item) {
+		return "store " + item;
+	}
+}
+
+trait Validated {
+	save(item) {
+		return super.save("valid " + item);
+	}
+}
+
+trait Logged <> Validated {
+	save(item) {
+		return "log(" + super.save(item) + ")";
+	}
+}
+
+trait Timestamped {
+	save(item) {
+		return super.save(item) + " at noon";
+	}
+}
+
+class Document < Store <> Logged {}
+class Note < Store <> Timestamped, Validated { -save } {}
+class Draft < Document <> Timestamped {}
+
+print Document().save("doc");
+print Note().save("note");
+print Draft().save("draft");
+`
+	assertPrograms(t, []testCase{
+		{program, "log(store valid doc)\nstore note at noon\nlog(store valid draft) at noon\n"},
+	})
+}
+
+func TestSuperErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"class A { class f() { return super.f(); } }", "[line 1] Can't use 'super' in a class with no superclass.\n"},
+		{"trait A { class f() { return super.f(); } }", "[line 1] Can't use 'super' outside of the instance methods of a trait.\n"},
+		{"trait A { var x = super.x; }", "[line 1] Can't use 'super' outside of the instance methods of a trait.\n"},
+		{"trait A { class set x(value) { print super.x; } }", "[line 1] Can't use 'super' outside of the instance methods of a trait.\n"},
+		{"trait A { f() { return super.f(); } }\nclass B <> A {}\nB().f();", "[line 1] Undefined property 'f'.\n"},
+	})
+}
@@ -0,0 +1,177 @@
+package test
+
+import "testing"
+
+func TestSwitchStatements(t *testing.T) {
+	program := `
+fun describe(n) {
+	switch (n) {
+		case 0:
+			return "zero";
+		case 1, 2, 3:
+			return "small";
+		default:
+			return "large";
+	}
+}
+print describe(0);
+print describe(2);
+print describe(10);
+
+var value = "b";
+switch (value) {
+	case "a":
+		print "first";
+	case "b":
+		var message = "second";
+		print message;
+	case "c":
+		print "third";
+}
+
+switch (5) {
+	case 1:
+		print "unreachable";
+}
+
+switch (1) {
+	case 1:
+		if (true) {
+			break;
+		}
+		print "unreachable";
+	default:
+		print "unreachable";
+}
+print "done";
+`
+	loop := `
+var i = 0;
+while (i < 4) {
+	i = i + 1;
+	switch (i) {
+		case 2:
+			continue;
+		case 3:
+			break;
+	}
+	print i;
+}
+`
+
+	assertPrograms(t, []testCase{
+		{program, "zero\nsmall\nlarge\nsecond\ndone\n"},
+		{loop, "1\n3\n4\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{"switch 1 { }", "[line 1] Error at '1': Expected '(' after 'switch'.\n"},
+		{"switch (1) { print 1; }", "[line 1] Error at 'print': Expected 'case' or 'default' inside of switch.\n"},
+		{"switch (1) { case 1 print 1; }", "[line 1] Error at 'print': Expected ':' after case values.\n"},
+		{"switch (1) { default: default: }", "[line 1] Error at 'default': A switch can only have one default case.\n"},
+		{"switch (1) { case 1: continue; }", "[line 1] Unexpected 'continue' outside of loop.\n"},
+	})
+}
+
+func TestSwitchSpecialMethods(t *testing.T) {
+	program := `
+class P {
+	init(x) {
+		this.x = x;
+	}
+
+	__eq__(other) {
+		return other is P and this.x == other.x;
+	}
+}
+
+print P(1) == P(1);
+switch (P(1)) {
+	case P(2):
+		print "two";
+	case P(1):
+		print "one";
+	default:
+		print "default";
+}
+switch (1) {
+	case P(1):
+		print "instance";
+	default:
+		print "number";
+}
+`
+	assertPrograms(t, []testCase{
+		{program, "true\none\nnumber\n"},
+	})
+}
+
+func TestDoWhileStatements(t *testing.T) {
+	program := `
+var i = 10;
+do {
+	print i;
+	i = i + 1;
+} while (i < 3);
+
+var j = 0;
+do {
+	j = j + 1;
+	if (j == 2) {
+		continue;
+	}
+	if (j == 4) {
+		break;
+	}
+	print j;
+} while (j < 10);
+`
+
+	assertPrograms(t, []testCase{
+		{program, "10\n1\n3\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{"do print 1; (true);", "[line 1] Error at '(': Expected 'while' after 'do' body.\n"},
+		{"do print 1; while (false)", "[line 1] Error at the end: Expected ';' after a 'do' loop.\n"},
+	})
+}
+
+func TestLabeledLoops(t *testing.T) {
+	program := `
+outer: for (var i = 0; i < 3; i = i + 1) {
+	for (var j = 0; j < 3; j = j + 1) {
+		if (j == 1) {
+			continue outer;
+		}
+		if (i == 2) {
+			break outer;
+		}
+		print [i, j];
+	}
+}
+
+var n = 0;
+rows: while (true) {
+	n = n + 1;
+	do {
+		switch (n) {
+			case 3:
+				break rows;
+		}
+	} while (false);
+}
+print n;
+`
+
+	assertPrograms(t, []testCase{
+		{program, "[0, 0]\n[1, 0]\n3\n"},
+	})
+
+	testFailingPrograms(t, []testCase{
+		{"outer: print 1;", "[line 1] Error at 'print': Expected a loop after label.\n"},
+		{"while (true) { break outer; }", "[line 1] No enclosing loop is labeled 'outer'.\n"},
+		{"loop: while (true) { loop: while (true) { break; } }", "[line 1] Label 'loop' is already used by an enclosing loop.\n"},
+		{"loop: while (true) { fun f() { continue loop; } }", "[line 1] No enclosing loop is labeled 'loop'.\n"},
+	})
+}
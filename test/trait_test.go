@@ -101,3 +101,98 @@ print MyMath().factorial(5);
 		{program2, "0.5\n0.07\n1\n0.15\n0.52\n0\nCalculating... sin 270\nCalculating... cos 360\n5\n120\n"},
 	})
 }
+
+func TestTraitRequirementsAndComposition(t *testing.T) {
+	program1 := `
+trait Greets {
+	required name();
+
+	greet() {
+		return "Hello, " + this.name() + "!";
+	}
+}
+
+trait Polite <> Greets {
+	farewell() {
+		return "Goodbye, " + this.name() + ".";
+	}
+}
+
+class Named {
+	name() {
+		return "Ada";
+	}
+}
+
+class Person < Named <> Polite {}
+
+var person = Person();
+print person.greet();
+print person.farewell();
+`
+
+	program2 := `
+trait Walks {
+	move() {
+		return "walking";
+	}
+}
+
+trait Swims {
+	move() {
+		return "swimming";
+	}
+}
+
+class Duck <> Walks { move as walk }, Swims { move as swim, -move } {}
+
+class Frog <> Walks, Swims {
+	move() {
+		return "hopping";
+	}
+}
+
+var duck = Duck();
+print duck.move();
+print duck.walk();
+print duck.swim();
+print Frog().move();
+`
+
+	program3 := `
+trait Base {
+	id() {
+		return "base";
+	}
+}
+
+trait Left <> Base {}
+trait Right <> Base {}
+
+class Both <> Left, Right {}
+print Both().id();
+`
+
+	assertPrograms(t, []testCase{
+		{program1, "Hello, Ada!\nGoodbye, Ada.\n"},
+		{program2, "walking\nwalking\nswimming\nhopping\n"},
+		{program3, "base\n"},
+	})
+}
+
+func TestTraitErrors(t *testing.T) {
+	testFailingPrograms(t, []testCase{
+		{"trait A { required name(); }\nclass B <> A {}", "[line 2] Class 'B' must implement method 'name' required by trait 'A'.\n"},
+		{"trait A { required name(); }\ntrait B <> A {}\nclass C <> B {}", "[line 3] Class 'C' must implement method 'name' required by trait 'A'.\n"},
+		{"trait A { f() {} }\ntrait B { f() {} }\nclass C <> A, B {}", "[line 3] Method 'f' is defined by both trait 'A' and trait 'B'.\n"},
+		{"trait A { class f() {} }\ntrait B { class f() {} }\ntrait C <> A, B {}", "[line 3] Static method 'f' is defined by both trait 'A' and trait 'B'.\n"},
+		{"trait A { f() {} }\ntrait B { f() {} }\nclass C <> A { f as g }, B {}", "[line 3] Method 'f' is defined by both trait 'A' and trait 'B'.\n"},
+		{"trait A { f() {} }\nclass C <> A { g as h } {}", "[line 2] Trait 'A' has no member 'g'.\n"},
+		{"trait A { f() {} }\nclass C <> A { -g } {}", "[line 2] Trait 'A' has no member 'g'.\n"},
+		{"trait A { f() {} g() {} }\nclass C <> A { f as g } {}", "[line 2] Trait 'A' already has a member 'g'.\n"},
+		{"class A { required f(); }", "[line 1] Error at 'f': Only traits can declare required methods.\n"},
+		{"trait A { class required f(); }", "[line 1] Error at 'required': Static methods can't be required.\n"},
+		{"trait A { required f() {} }", "[line 1] Error at '{': Expected ';' after a required method.\n"},
+		{"trait A { f() {} g() {} }\nclass C <> A { f as h, g } {}", "[line 2] Error at '}': Expected 'as' after member name.\n"},
+	})
+}
@@ -84,7 +84,7 @@ func main() {
 
 	defineAst(outputDir, "Expr", []string{
 		"Array 		: Elements []Expr, Bracket scanner.Token",
-		"Ternary  	: Condition Expr, Left Expr, Right Expr",
+		"Ternary  	: Condition Expr, Question scanner.Token, Left Expr, Right Expr",
 		"Assignment : Name scanner.Token, Value Expr",
 		"Logical	: Left Expr, Operator scanner.Token, Right Expr",
 		"Set		: Object Expr, Name scanner.Token, Value Expr",
@@ -94,27 +94,40 @@ func main() {
 		"Grouping	: Expr Expr",
 		"Literal	: Value any",
 		"Unary		: Operator scanner.Token, Right Expr",
-		"Get		: Object Expr, Name scanner.Token",
-		"ArrayGet	: Array Expr, Bracket scanner.Token, Index Expr",
-		"Call		: Callee Expr, Parenthesis scanner.Token, Arguments []Expr",
-		"Lambda		: Parenthesis scanner.Token, Parameters []scanner.Token, Body []Stmt",
+		"Get		: Object Expr, Name scanner.Token, Optional bool",
+		"ArrayGet	: Array Expr, Bracket scanner.Token, Index Expr, Optional bool",
+		"OptionalChain : Expr Expr",
+		"Call		: Callee Expr, Parenthesis scanner.Token, Arguments []Expr, NamedArguments []NamedArgument, Optional bool",
+		"Lambda		: Parenthesis scanner.Token, Parameters []Parameter, ReturnType *TypeAnnotation, Body []Stmt",
 		"This 		: Keyword scanner.Token",
 		"Variable 	: Name scanner.Token",
+		"Match 		: Keyword scanner.Token, Subject Expr, Cases []MatchCase",
+		"Spread 	: Ellipsis scanner.Token, Expr Expr",
+		"Range 		: Start Expr, Operator scanner.Token, End Expr, Step Expr",
+		"Comprehension : Bracket scanner.Token, Element Expr, Name scanner.Token, Iterable Expr, Condition Expr",
+		"DestructureAssignment : Bracket scanner.Token, Targets []Expr, Rest Expr, Value Expr",
 	})
 
 	defineAst(outputDir, "Stmt", []string{
 		"Expression : Expression Expr",
-		"Print      : Expression Expr",
-		"Var 		: Name scanner.Token, Initializer Expr",
-		"Class 		: Name scanner.Token, Superclass VariableExpr, Traits []VariableExpr, Methods []FunctionStmt, StaticMethods []FunctionStmt",
-		"Trait 		: Name scanner.Token, Methods []FunctionStmt, StaticMethods []FunctionStmt",
-		"Function 	: Name scanner.Token, Parameters []scanner.Token, Body []Stmt",
+		"Print      : Keyword scanner.Token, Expression Expr",
+		"Var 		: Name scanner.Token, Type *TypeAnnotation, Initializer Expr, Constant bool",
+		"Destructure : Keyword scanner.Token, Pattern Pattern, Initializer Expr",
+		"Class 		: Name scanner.Token, Abstract bool, Superclass VariableExpr, Traits []TraitUse, AbstractMethods []FunctionStmt, Methods []FunctionStmt, StaticMethods []FunctionStmt, Setters []FunctionStmt, StaticSetters []FunctionStmt, Fields []VarStmt, StaticFields []VarStmt",
+		"Trait 		: Name scanner.Token, Traits []TraitUse, Required []FunctionStmt, Methods []FunctionStmt, StaticMethods []FunctionStmt, Setters []FunctionStmt, StaticSetters []FunctionStmt, Fields []VarStmt, StaticFields []VarStmt",
+		"Enum 		: Name scanner.Token, Variants []EnumVariant, Methods []FunctionStmt, StaticMethods []FunctionStmt",
+		"Function 	: Name scanner.Token, Parameters []Parameter, ReturnType *TypeAnnotation, Body []Stmt",
 		"Block 		: Declarations []Stmt",
-		"If 		: Expression Expr, ThenBranch Stmt, ElseBranch Stmt",
-		"While 		: Condition Expr, Body Stmt",
-		"For 		: Initializer Stmt, Condition Expr, Increment Stmt, Body Stmt",
-		"Break 		: Keyword scanner.Token",
-		"Continue 	: Keyword scanner.Token",
+		"If 		: Keyword scanner.Token, Expression Expr, ThenBranch Stmt, ElseBranch Stmt",
+		"While 		: Keyword scanner.Token, Condition Expr, Body Stmt, Label scanner.Token",
+		"DoWhile 	: Keyword scanner.Token, Body Stmt, Condition Expr, Label scanner.Token",
+		"For 		: Keyword scanner.Token, Initializer Stmt, Condition Expr, Increment Stmt, Body Stmt, Label scanner.Token",
+		"Switch 	: Keyword scanner.Token, Subject Expr, Cases []SwitchCase, Default []Stmt",
+		"Break 		: Keyword scanner.Token, Label scanner.Token",
+		"Continue 	: Keyword scanner.Token, Label scanner.Token",
 		"Return 	: Keyword scanner.Token, Expr Expr",
+		"Defer 		: Keyword scanner.Token, Expr Expr",
+		"Using 		: Keyword scanner.Token, Name scanner.Token, Initializer Expr, Body Stmt",
+		"Assert 	: Keyword scanner.Token, Condition Expr, Message Expr",
 	})
 }